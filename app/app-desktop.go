@@ -31,13 +31,28 @@ type Application struct {
 // App returns the Application singleton, creating it the first time.
 func App(width, height int, title string) *Application {
 
+	return newApp(func() error { return window.Init(width, height, title) })
+}
+
+// AppHidden returns the Application singleton, creating it the first time
+// with an invisible window (see window.InitHidden), for programs which only
+// render offscreen or process data on the GPU.
+func AppHidden(width, height int) *Application {
+
+	return newApp(func() error { return window.InitHidden(width, height) })
+}
+
+// newApp returns the Application singleton, creating it the first time
+// with the window initialized by the specified function.
+func newApp(initWindow func() error) *Application {
+
 	// Return singleton if already created
 	if a != nil {
 		return a
 	}
 	a = new(Application)
 	// Initialize window
-	err := window.Init(width, height, title)
+	err := initWindow()
 	if err != nil {
 		panic(err)
 	}
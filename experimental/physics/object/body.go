@@ -550,6 +550,18 @@ func (b *Body) VectorToWorld(localVector *math32.Vector3) math32.Vector3 {
 	return *localVector.Clone().ApplyQuaternion(b.quaternion)
 }
 
+// ShiftOrigin translates the body's world space state by the negated offset.
+// It is used when the world origin is moved (e.g. by a floating origin manager).
+func (b *Body) ShiftOrigin(offset *math32.Vector3) {
+
+	b.position.Sub(offset)
+	b.prevPosition.Sub(offset)
+	b.interpPosition.Sub(offset)
+	b.initPosition.Sub(offset)
+	b.GetNode().SetPositionVec(b.position)
+	b.aabbNeedsUpdate = true
+}
+
 // UpdateEffectiveMassProperties
 // If the body is sleeping, it should be immovable and thus have infinite mass during solve.
 // This is solved by having a separate "effective mass" and other "effective" properties
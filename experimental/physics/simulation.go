@@ -157,6 +157,18 @@ func (s *Simulation) RemoveBody(body *object.Body) bool {
 	return false
 }
 
+// ShiftOrigin translates all the bodies under simulation by the negated offset.
+// It should be called whenever the world origin is moved, for example
+// from a subscription to the util.OnOriginShift event.
+func (s *Simulation) ShiftOrigin(offset *math32.Vector3) {
+
+	for _, b := range s.bodies {
+		if b != nil {
+			b.ShiftOrigin(offset)
+		}
+	}
+}
+
 // Clean removes nil bodies from the bodies array, recalculates the body indices and updates the collision matrix.
 //func (s *Simulation) Clean() {
 //
@@ -0,0 +1,74 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gls
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+// SetThreadCheck enables/disables checking that buffer and draw calls are made
+// from the goroutine which owns the OpenGL context.
+// When enabled, the calling goroutine is recorded as the owner, so it should be
+// called from the goroutine which made the context current (normally the main
+// goroutine, which is locked to the main OS thread by the window package).
+// Calls made from other goroutines are logged as errors.
+// It is disabled by default as it adds some overhead to each checked call.
+func (gs *GLS) SetThreadCheck(enable bool) {
+
+	if enable {
+		gs.owner = goroutineID()
+	} else {
+		gs.owner = 0
+	}
+}
+
+// ThreadCheck returns if the goroutine affinity check is enabled or not.
+func (gs *GLS) ThreadCheck() bool {
+
+	return gs.owner != 0
+}
+
+// CheckThread returns an error if the goroutine affinity check is enabled
+// and the calling goroutine is not the one which owns the OpenGL context.
+func (gs *GLS) CheckThread() error {
+
+	if gs.owner == 0 {
+		return nil
+	}
+	id := goroutineID()
+	if id != gs.owner {
+		return fmt.Errorf("OpenGL called from goroutine %d but the context is owned by goroutine %d", id, gs.owner)
+	}
+	return nil
+}
+
+// checkThread logs an error with the name of the called function
+// if the goroutine affinity check fails.
+func (gs *GLS) checkThread(fname string) {
+
+	if gs.owner == 0 {
+		return
+	}
+	if err := gs.CheckThread(); err != nil {
+		log.Error("%s: %v", fname, err)
+	}
+}
+
+// goroutineID returns the id of the calling goroutine,
+// parsed from the header of its stack trace ("goroutine <id> [...").
+func goroutineID() uint64 {
+
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
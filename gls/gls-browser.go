@@ -21,6 +21,8 @@ type GLS struct {
 	prog        *Program          // current active shader program
 	programs    map[*Program]bool // shader programs cache
 	checkErrors bool              // check openGL API errors flag
+	tracker     *objTracker       // optional OpenGL object tracker
+	owner       uint64            // id of the goroutine owning the context (0 if not checked)
 
 	// Cache WebGL state to avoid making unnecessary API calls
 	activeTexture       uint32      // cached last set active texture unit
@@ -206,6 +208,7 @@ func (gs *GLS) AttachShader(program, shader uint32) {
 // BindBuffer binds a buffer object to the specified buffer binding point.
 func (gs *GLS) BindBuffer(target int, vbo uint32) {
 
+	gs.checkThread("BindBuffer")
 	gs.gl.Call("bindBuffer", target, gs.bufferMap[vbo])
 	gs.checkError("BindBuffer")
 }
@@ -281,6 +284,7 @@ func (gs *GLS) BlendFuncSeparate(srcRGB uint32, dstRGB uint32, srcAlpha uint32,
 // bound to target, deleting any pre-existing data store.
 func (gs *GLS) BufferData(target uint32, size int, data interface{}, usage uint32) {
 
+	gs.checkThread("BufferData")
 	dataTA, free := wasm.SliceToTypedArray(data)
 	gs.gl.Call("bufferData", int(target), dataTA, int(usage))
 	gs.checkError("BufferData")
@@ -333,6 +337,7 @@ func (gs *GLS) CreateProgram() uint32 {
 	gs.checkError("CreateProgram")
 	idx := gs.programMapIndex
 	gs.programMapIndex++
+	gs.track(ObjProgram, idx)
 	return idx
 }
 
@@ -344,6 +349,7 @@ func (gs *GLS) CreateShader(stype uint32) uint32 {
 	gs.checkError("CreateShader")
 	idx := gs.shaderMapIndex
 	gs.shaderMapIndex++
+	gs.track(ObjShader, idx)
 	return idx
 }
 
@@ -351,12 +357,14 @@ func (gs *GLS) CreateShader(stype uint32) uint32 {
 // by the elements of the provided array.
 func (gs *GLS) DeleteBuffers(bufs ...uint32) {
 
+	gs.checkThread("DeleteBuffers")
 	for _, buf := range bufs {
 		gs.gl.Call("deleteBuffer", gs.bufferMap[buf])
 		gs.checkError("DeleteBuffers")
 		gs.stats.Buffers--
 		delete(gs.bufferMap, buf)
 	}
+	gs.untrack(ObjBuffer, bufs...)
 }
 
 // DeleteShader frees the memory and invalidates the name
@@ -366,6 +374,7 @@ func (gs *GLS) DeleteShader(shader uint32) {
 	gs.gl.Call("deleteShader", gs.shaderMap[shader])
 	gs.checkError("DeleteShader")
 	delete(gs.shaderMap, shader)
+	gs.untrack(ObjShader, shader)
 }
 
 // DeleteProgram frees the memory and invalidates the name
@@ -375,6 +384,7 @@ func (gs *GLS) DeleteProgram(program uint32) {
 	gs.gl.Call("deleteProgram", gs.programMap[program])
 	gs.checkError("DeleteProgram")
 	delete(gs.programMap, program)
+	gs.untrack(ObjProgram, program)
 }
 
 // DeleteTextures deletes n​textures named
@@ -387,6 +397,7 @@ func (gs *GLS) DeleteTextures(tex ...uint32) {
 		delete(gs.textureMap, t)
 		gs.stats.Textures--
 	}
+	gs.untrack(ObjTexture, tex...)
 }
 
 // DeleteVertexArrays deletes n​vertex array objects named
@@ -399,6 +410,7 @@ func (gs *GLS) DeleteVertexArrays(vaos ...uint32) {
 		delete(gs.vertexArrayMap, v)
 		gs.stats.Vaos--
 	}
+	gs.untrack(ObjVertexArray, vaos...)
 }
 
 // TODO ReadPixels
@@ -436,6 +448,7 @@ func (gs *GLS) DepthMask(flag bool) {
 // DrawArrays renders primitives from array data.
 func (gs *GLS) DrawArrays(mode uint32, first int32, count int32) {
 
+	gs.checkThread("DrawArrays")
 	gs.gl.Call("drawArrays", int(mode), first, count)
 	gs.checkError("DrawArrays")
 	gs.stats.Drawcalls++
@@ -444,6 +457,7 @@ func (gs *GLS) DrawArrays(mode uint32, first int32, count int32) {
 // DrawElements renders primitives from array data.
 func (gs *GLS) DrawElements(mode uint32, count int32, itype uint32, start uint32) {
 
+	gs.checkThread("DrawElements")
 	gs.gl.Call("drawElements", int(mode), count, int(itype), start)
 	gs.checkError("DrawElements")
 	gs.stats.Drawcalls++
@@ -501,11 +515,13 @@ func (gs *GLS) FrontFace(mode uint32) {
 // GenBuffer generates a ​buffer object name.
 func (gs *GLS) GenBuffer() uint32 {
 
+	gs.checkThread("GenBuffer")
 	gs.bufferMap[gs.bufferMapIndex] = gs.gl.Call("createBuffer")
 	gs.checkError("CreateBuffer")
 	idx := gs.bufferMapIndex
 	gs.bufferMapIndex++
 	gs.stats.Buffers++
+	gs.track(ObjBuffer, idx)
 	return idx
 }
 
@@ -524,6 +540,7 @@ func (gs *GLS) GenTexture() uint32 {
 	idx := gs.textureMapIndex
 	gs.textureMapIndex++
 	gs.stats.Textures++
+	gs.track(ObjTexture, idx)
 	return idx
 }
 
@@ -535,6 +552,7 @@ func (gs *GLS) GenVertexArray() uint32 {
 	idx := gs.vertexArrayMapIndex
 	gs.vertexArrayMapIndex++
 	gs.stats.Vaos++
+	gs.track(ObjVertexArray, idx)
 	return idx
 }
 
@@ -704,6 +722,14 @@ func (gs *GLS) Uniform1i(location int32, v0 int32) {
 	gs.stats.Unisets++
 }
 
+// Uniform1ui sets the value of an unsigned int uniform variable for the current program object.
+func (gs *GLS) Uniform1ui(location int32, v0 uint32) {
+
+	gs.gl.Call("uniform1ui", gs.uniformMap[uint32(location)], v0)
+	gs.checkError("Uniform1ui")
+	gs.stats.Unisets++
+}
+
 // Uniform1f sets the value of a float uniform variable for the current program object.
 func (gs *GLS) Uniform1f(location int32, v0 float32) {
 
@@ -838,6 +864,14 @@ func (gs *GLS) UseProgram(prog *Program) {
 	}
 }
 
+// UnbindProgram deactivates the current shader program, if any.
+func (gs *GLS) UnbindProgram() {
+
+	gs.gl.Call("useProgram", js.Null())
+	gs.checkError("UnbindProgram")
+	gs.prog = nil
+}
+
 // checkError checks if there are any WebGL errors and panics if so.
 func (gs *GLS) checkError(name string) {
 
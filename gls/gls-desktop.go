@@ -25,6 +25,8 @@ type GLS struct {
 	prog        *Program          // current active shader program
 	programs    map[*Program]bool // shader programs cache
 	checkErrors bool              // check openGL API errors flag
+	tracker     *objTracker       // optional OpenGL object tracker
+	owner       uint64            // id of the goroutine owning the context (0 if not checked)
 
 	// Cache OpenGL state to avoid making unnecessary API calls
 	activeTexture  uint32  // cached last set active texture unit
@@ -184,6 +186,7 @@ func (gs *GLS) AttachShader(program, shader uint32) {
 // BindBuffer binds a buffer object to the specified buffer binding point.
 func (gs *GLS) BindBuffer(target int, vbo uint32) {
 
+	gs.checkThread("BindBuffer")
 	C.glBindBuffer(C.GLenum(target), C.GLuint(vbo))
 }
 
@@ -252,6 +255,7 @@ func (gs *GLS) BlendFuncSeparate(srcRGB uint32, dstRGB uint32, srcAlpha uint32,
 // bound to target, deleting any pre-existing data store.
 func (gs *GLS) BufferData(target uint32, size int, data interface{}, usage uint32) {
 
+	gs.checkThread("BufferData")
 	C.glBufferData(C.GLenum(target), C.GLsizeiptr(size), ptr(data), C.GLenum(usage))
 }
 
@@ -281,6 +285,14 @@ func (gs *GLS) Clear(mask uint) {
 	C.glClear(C.GLbitfield(mask))
 }
 
+// ClearBufferuiv clears the specified draw buffer of the current framebuffer,
+// which must have an unsigned integer format, to the specified values.
+// Buffer must be COLOR. Unsigned integer buffers cannot be cleared by Clear.
+func (gs *GLS) ClearBufferuiv(buffer uint32, drawbuffer int32, values [4]uint32) {
+
+	C.glClearBufferuiv(C.GLenum(buffer), C.GLint(drawbuffer), (*C.GLuint)(&values[0]))
+}
+
 // CompileShader compiles the source code strings that
 // have been stored in the specified shader object.
 func (gs *GLS) CompileShader(shader uint32) {
@@ -293,6 +305,7 @@ func (gs *GLS) CompileShader(shader uint32) {
 func (gs *GLS) CreateProgram() uint32 {
 
 	p := C.glCreateProgram()
+	gs.track(ObjProgram, uint32(p))
 	return uint32(p)
 }
 
@@ -301,6 +314,7 @@ func (gs *GLS) CreateProgram() uint32 {
 func (gs *GLS) CreateShader(stype uint32) uint32 {
 
 	h := C.glCreateShader(C.GLenum(stype))
+	gs.track(ObjShader, uint32(h))
 	return uint32(h)
 }
 
@@ -308,8 +322,10 @@ func (gs *GLS) CreateShader(stype uint32) uint32 {
 // by the elements of the provided array.
 func (gs *GLS) DeleteBuffers(bufs ...uint32) {
 
+	gs.checkThread("DeleteBuffers")
 	C.glDeleteBuffers(C.GLsizei(len(bufs)), (*C.GLuint)(&bufs[0]))
 	gs.stats.Buffers -= len(bufs)
+	gs.untrack(ObjBuffer, bufs...)
 }
 
 // DeleteShader frees the memory and invalidates the name
@@ -317,6 +333,7 @@ func (gs *GLS) DeleteBuffers(bufs ...uint32) {
 func (gs *GLS) DeleteShader(shader uint32) {
 
 	C.glDeleteShader(C.GLuint(shader))
+	gs.untrack(ObjShader, shader)
 }
 
 // DeleteProgram frees the memory and invalidates the name
@@ -324,6 +341,7 @@ func (gs *GLS) DeleteShader(shader uint32) {
 func (gs *GLS) DeleteProgram(program uint32) {
 
 	C.glDeleteProgram(C.GLuint(program))
+	gs.untrack(ObjProgram, program)
 }
 
 // DeleteTextures deletes n​textures named
@@ -332,6 +350,7 @@ func (gs *GLS) DeleteTextures(tex ...uint32) {
 
 	C.glDeleteTextures(C.GLsizei(len(tex)), (*C.GLuint)(&tex[0]))
 	gs.stats.Textures -= len(tex)
+	gs.untrack(ObjTexture, tex...)
 }
 
 // DeleteVertexArrays deletes n​vertex array objects named
@@ -340,6 +359,23 @@ func (gs *GLS) DeleteVertexArrays(vaos ...uint32) {
 
 	C.glDeleteVertexArrays(C.GLsizei(len(vaos)), (*C.GLuint)(&vaos[0]))
 	gs.stats.Vaos -= len(vaos)
+	gs.untrack(ObjVertexArray, vaos...)
+}
+
+// DeleteFramebuffers deletes the framebuffers named
+// by the elements of the provided array.
+func (gs *GLS) DeleteFramebuffers(fbs ...uint32) {
+
+	C.glDeleteFramebuffers(C.GLsizei(len(fbs)), (*C.GLuint)(&fbs[0]))
+	gs.stats.Fbos -= uint64(len(fbs))
+}
+
+// DeleteRenderbuffers deletes the render buffers named
+// by the elements of the provided array.
+func (gs *GLS) DeleteRenderbuffers(rbs ...uint32) {
+
+	C.glDeleteRenderbuffers(C.GLsizei(len(rbs)), (*C.GLuint)(&rbs[0]))
+	gs.stats.Rbos -= uint64(len(rbs))
 }
 
 // ReadPixels returns the current rendered image.
@@ -354,6 +390,70 @@ func (gs *GLS) ReadPixels(x, y, width, height, format, formatType int) []byte {
 	return gs.gobuf[:size]
 }
 
+// ReadPixelsBuffer reads a block of pixels from the frame buffer into the buffer
+// currently bound to the PIXEL_PACK_BUFFER target, starting at its first byte.
+// The call returns without waiting for the pixels to be transferred.
+// more information: http://docs.gl/gl3/glReadPixels
+func (gs *GLS) ReadPixelsBuffer(x, y, width, height int32, format, formatType uint32) {
+
+	C.glReadPixels(C.GLint(x), C.GLint(y), C.GLsizei(width), C.GLsizei(height), C.GLenum(format), C.GLenum(formatType), nil)
+}
+
+// GetTexImageBuffer reads the image of the texture currently bound to the specified
+// target into the buffer currently bound to the PIXEL_PACK_BUFFER target,
+// starting at its first byte.
+// more information: http://docs.gl/gl3/glGetTexImage
+func (gs *GLS) GetTexImageBuffer(target uint32, level int32, format, formatType uint32) {
+
+	C.glGetTexImage(C.GLenum(target), C.GLint(level), C.GLenum(format), C.GLenum(formatType), nil)
+}
+
+// MapBufferRange maps the specified range of the buffer bound to the specified target
+// into client memory and returns a slice referencing it.
+// The slice is only valid until UnmapBuffer is called.
+// Returns nil if the buffer could not be mapped.
+func (gs *GLS) MapBufferRange(target uint32, offset, length int, access uint32) []byte {
+
+	p := C.glMapBufferRange(C.GLenum(target), C.GLintptr(offset), C.GLsizeiptr(length), C.GLbitfield(access))
+	if p == nil {
+		return nil
+	}
+	return (*[1 << 30]byte)(p)[:length:length]
+}
+
+// UnmapBuffer releases the mapping of the buffer bound to the specified target.
+// Returns false if the buffer contents became corrupt while mapped.
+func (gs *GLS) UnmapBuffer(target uint32) bool {
+
+	return C.glUnmapBuffer(C.GLenum(target)) == C.GL_TRUE
+}
+
+// Sync is an OpenGL sync object.
+type Sync struct {
+	handle C.GLsync
+}
+
+// FenceSync creates a new sync object which is signaled when all
+// the previously issued commands are completed.
+func (gs *GLS) FenceSync() Sync {
+
+	return Sync{C.glFenceSync(SYNC_GPU_COMMANDS_COMPLETE, 0)}
+}
+
+// ClientWaitSync waits at most timeout nanoseconds for the specified sync object
+// to be signaled. Returns ALREADY_SIGNALED, CONDITION_SATISFIED, TIMEOUT_EXPIRED or WAIT_FAILED.
+// A zero timeout only checks the state of the sync object.
+func (gs *GLS) ClientWaitSync(sync Sync, flags uint32, timeout uint64) uint32 {
+
+	return uint32(C.glClientWaitSync(sync.handle, C.GLbitfield(flags), C.GLuint64(timeout)))
+}
+
+// DeleteSync deletes the specified sync object.
+func (gs *GLS) DeleteSync(sync Sync) {
+
+	C.glDeleteSync(sync.handle)
+}
+
 // DepthFunc specifies the function used to compare each incoming pixel
 // depth value with the depth value present in the depth buffer.
 func (gs *GLS) DepthFunc(mode uint32) {
@@ -408,6 +508,7 @@ func (gs *GLS) StencilMask(mask uint32) {
 // DrawArrays renders primitives from array data.
 func (gs *GLS) DrawArrays(mode uint32, first int32, count int32) {
 
+	gs.checkThread("DrawArrays")
 	C.glDrawArrays(C.GLenum(mode), C.GLint(first), C.GLsizei(count))
 	gs.stats.Drawcalls++
 }
@@ -415,6 +516,7 @@ func (gs *GLS) DrawArrays(mode uint32, first int32, count int32) {
 // DrawElements renders primitives from array data.
 func (gs *GLS) DrawElements(mode uint32, count int32, itype uint32, start uint32) {
 
+	gs.checkThread("DrawElements")
 	C.glDrawElements(C.GLenum(mode), C.GLsizei(count), C.GLenum(itype), unsafe.Pointer(uintptr(start)))
 	gs.stats.Drawcalls++
 }
@@ -473,9 +575,11 @@ func (gs *GLS) FrontFace(mode uint32) {
 // GenBuffer generates a ​buffer object name.
 func (gs *GLS) GenBuffer() uint32 {
 
+	gs.checkThread("GenBuffer")
 	var buf uint32
 	C.glGenBuffers(1, (*C.GLuint)(&buf))
 	gs.stats.Buffers++
+	gs.track(ObjBuffer, buf)
 	return buf
 }
 
@@ -573,6 +677,7 @@ func (gs *GLS) GenTexture() uint32 {
 	var tex uint32
 	C.glGenTextures(1, (*C.GLuint)(&tex))
 	gs.stats.Textures++
+	gs.track(ObjTexture, tex)
 	return tex
 }
 
@@ -582,6 +687,7 @@ func (gs *GLS) GenVertexArray() uint32 {
 	var vao uint32
 	C.glGenVertexArrays(1, (*C.GLuint)(&vao))
 	gs.stats.Vaos++
+	gs.track(ObjVertexArray, vao)
 	return vao
 }
 
@@ -739,6 +845,13 @@ func (gs *GLS) Uniform1i(location int32, v0 int32) {
 	gs.stats.Unisets++
 }
 
+// Uniform1ui sets the value of an unsigned int uniform variable for the current program object.
+func (gs *GLS) Uniform1ui(location int32, v0 uint32) {
+
+	C.glUniform1ui(C.GLint(location), C.GLuint(v0))
+	gs.stats.Unisets++
+}
+
 // Uniform1f sets the value of a float uniform variable for the current program object.
 func (gs *GLS) Uniform1f(location int32, v0 float32) {
 
@@ -841,6 +954,13 @@ func (gs *GLS) UseProgram(prog *Program) {
 	}
 }
 
+// UnbindProgram deactivates the current shader program, if any.
+func (gs *GLS) UnbindProgram() {
+
+	C.glUseProgram(0)
+	gs.prog = nil
+}
+
 // Ptr takes a slice or pointer (to a singular scalar value or the first
 // element of an array or slice) and returns its GL-compatible address.
 //
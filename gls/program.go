@@ -9,10 +9,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
+// Errors returned when building a Program.
+// The compile and link errors are wrapped with the shader type and
+// the OpenGL info log, so they should be checked with errors.Is.
+var (
+	ErrProgramBuilt = errors.New("program already built")
+	ErrNoShaders    = errors.New("no shaders supplied")
+	ErrCompile      = errors.New("error compiling")
+	ErrLink         = errors.New("error linking program")
+)
+
 // Program represents an OpenGL program.
 // It must have Vertex and Fragment shaders.
 // It can also have a Geometry shader.
@@ -26,11 +37,26 @@ type Program struct {
 
 // shaderInfo contains OpenGL-related shader information.
 type shaderInfo struct {
-	stype  uint32 // OpenGL shader type (VERTEX_SHADER, FRAGMENT_SHADER, or GEOMETRY_SHADER)
-	source string // Shader source code
-	handle uint32 // OpenGL shader handle
+	stype  uint32    // OpenGL shader type (VERTEX_SHADER, FRAGMENT_SHADER, or GEOMETRY_SHADER)
+	source string    // Shader source code
+	smap   SourceMap // Optional map from source lines to their original locations
+	handle uint32    // OpenGL shader handle
+}
+
+// SourceLocation identifies a line of an original shader source or include chunk.
+type SourceLocation struct {
+	File string // Name of the shader or include chunk
+	Line int    // Line number in the original source starting at 1
 }
 
+// SourceMap maps each line of a preprocessed shader source
+// (by index starting at 0) to its location in the original sources.
+type SourceMap []SourceLocation
+
+// Regular expression to parse the line reference prefix of shader info log lines
+// in the formats used by the most common drivers: "0:12", "0(12)" and "ERROR: 0:12"
+var rexLogLine = regexp.MustCompile(`^(\s*(?:ERROR|WARNING):\s*)?\d+(?::(\d+)|\((\d+)\))`)
+
 // Map from shader types to names.
 var shaderNames = map[uint32]string{
 	VERTEX_SHADER:   "Vertex Shader",
@@ -58,23 +84,29 @@ func (prog *Program) Handle() uint32 {
 }
 
 // AddShader adds a shader to this program.
+// An optional source map of the preprocessed source can be supplied and is
+// used to report compilation errors at their original file and line.
 // This must be done before the program is built.
-func (prog *Program) AddShader(stype uint32, source string) {
+func (prog *Program) AddShader(stype uint32, source string, smap ...SourceMap) {
 
 	// Check if program already built
 	if prog.handle != 0 {
 		log.Fatal("Program already built")
 	}
-	prog.shaders = append(prog.shaders, shaderInfo{stype, source, 0})
+	var m SourceMap
+	if len(smap) > 0 {
+		m = smap[0]
+	}
+	prog.shaders = append(prog.shaders, shaderInfo{stype, source, m, 0})
 }
 
 // DeleteShaders deletes all of this program's shaders from OpenGL.
 func (prog *Program) DeleteShaders() {
 
-	for _, shaderInfo := range prog.shaders {
-		if shaderInfo.handle != 0 {
-			prog.gs.DeleteShader(shaderInfo.handle)
-			shaderInfo.handle = 0
+	for i := range prog.shaders {
+		if prog.shaders[i].handle != 0 {
+			prog.gs.DeleteShader(prog.shaders[i].handle)
+			prog.shaders[i].handle = 0
 		}
 	}
 }
@@ -84,12 +116,12 @@ func (prog *Program) Build() error {
 
 	// Check if program already built
 	if prog.handle != 0 {
-		return fmt.Errorf("program already built")
+		return ErrProgramBuilt
 	}
 
 	// Check if shaders were provided
 	if len(prog.shaders) == 0 {
-		return fmt.Errorf("no shaders supplied")
+		return ErrNoShaders
 	}
 
 	// Create program
@@ -102,18 +134,23 @@ func (prog *Program) Build() error {
 	defer prog.DeleteShaders()
 
 	// Compile and attach shaders
-	for _, sinfo := range prog.shaders {
+	for i, sinfo := range prog.shaders {
 		shader, err := prog.CompileShader(sinfo.stype, sinfo.source)
+		if shader != 0 {
+			prog.shaders[i].handle = shader
+		}
 		if err != nil {
 			prog.gs.DeleteProgram(prog.handle)
 			prog.handle = 0
-			msg := fmt.Sprintf("error compiling %s: %s", shaderNames[sinfo.stype], err)
+			if sinfo.smap != nil {
+				err = errors.New(sinfo.smap.MapLog(err.Error(), sinfo.source))
+			}
+			msg := fmt.Sprintf("%s: %s", shaderNames[sinfo.stype], err)
 			if prog.ShowSource {
 				msg += FormatSource(sinfo.source)
 			}
-			return errors.New(msg)
+			return fmt.Errorf("%w %s", ErrCompile, msg)
 		}
-		sinfo.handle = shader
 		prog.gs.AttachShader(prog.handle, shader)
 	}
 
@@ -123,13 +160,30 @@ func (prog *Program) Build() error {
 	prog.gs.GetProgramiv(prog.handle, LINK_STATUS, &status)
 	if status == FALSE {
 		log := prog.gs.GetProgramInfoLog(prog.handle)
+		prog.gs.DeleteProgram(prog.handle)
 		prog.handle = 0
-		return fmt.Errorf("error linking program: %v", log)
+		return fmt.Errorf("%w: %v", ErrLink, log)
 	}
 
 	return nil
 }
 
+// Dispose deletes this program from OpenGL and removes it from the GLS programs cache.
+// If it is the current program it is unbound first.
+// The program must not be built or used again after being disposed.
+func (prog *Program) Dispose() {
+
+	if prog.handle == 0 {
+		return
+	}
+	if prog.gs.prog == prog {
+		prog.gs.UnbindProgram()
+	}
+	prog.gs.DeleteProgram(prog.handle)
+	delete(prog.gs.programs, prog)
+	prog.handle = 0
+}
+
 // GetAttribLocation returns the location of the specified attribute
 // in this program. This location is internally cached.
 func (prog *Program) GetAttribLocation(name string) int32 {
@@ -225,3 +279,41 @@ func FormatSource(source string) string {
 
 	return strings.Join(formatted, "\n")
 }
+
+// MapLog rewrites the line references of the specified shader info log,
+// which refer to the preprocessed source, as "file:line" of the original
+// sources and appends the offending source line after each message.
+func (sm SourceMap) MapLog(infolog, source string) string {
+
+	lines := strings.Split(source, "\n")
+	mapped := make([]string, 0)
+	for _, l := range strings.Split(infolog, "\n") {
+		m := rexLogLine.FindStringSubmatchIndex(l)
+		if m == nil {
+			mapped = append(mapped, l)
+			continue
+		}
+		// Line number is either in the "0:12" or in the "0(12)" form
+		num := ""
+		if m[4] >= 0 {
+			num = l[m[4]:m[5]]
+		} else {
+			num = l[m[6]:m[7]]
+		}
+		n, _ := strconv.Atoi(num)
+		if n < 1 || n > len(sm) {
+			mapped = append(mapped, l)
+			continue
+		}
+		prefix := ""
+		if m[2] >= 0 {
+			prefix = l[m[2]:m[3]]
+		}
+		loc := sm[n-1]
+		mapped = append(mapped, fmt.Sprintf("%s%s:%d%s", prefix, loc.File, loc.Line, l[m[1]:]))
+		if n <= len(lines) {
+			mapped = append(mapped, "    "+strings.TrimSpace(lines[n-1]))
+		}
+	}
+	return strings.Join(mapped, "\n")
+}
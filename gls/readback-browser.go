@@ -0,0 +1,50 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build wasm
+// +build wasm
+
+package gls
+
+import (
+	"fmt"
+)
+
+// Readback transfers pixels from the GPU to client memory asynchronously.
+// Asynchronous readback is not supported in the browser: all transfers fail.
+type Readback struct{}
+
+// NewReadback creates and returns a pointer to a new asynchronous pixels reader.
+func (gs *GLS) NewReadback() *Readback {
+
+	return new(Readback)
+}
+
+// ReadPixels is not supported in the browser and always returns an error.
+func (rb *Readback) ReadPixels(x, y, width, height int32, format, formatType uint32, cb func(data []byte, err error)) error {
+
+	return fmt.Errorf("readback not supported in the browser")
+}
+
+// ReadTexture is not supported in the browser and always returns an error.
+func (rb *Readback) ReadTexture(tex uint32, level, width, height int32, format, formatType uint32, cb func(data []byte, err error)) error {
+
+	return fmt.Errorf("readback not supported in the browser")
+}
+
+// Pending returns if there is a transfer in progress, which is always false.
+func (rb *Readback) Pending() bool {
+
+	return false
+}
+
+// Poll does nothing and returns false.
+func (rb *Readback) Poll() bool {
+
+	return false
+}
+
+// Dispose does nothing.
+func (rb *Readback) Dispose() {
+}
@@ -0,0 +1,151 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !wasm
+// +build !wasm
+
+package gls
+
+import (
+	"fmt"
+)
+
+// Readback transfers pixels from the GPU to client memory asynchronously.
+// The pixels are read into a pixel pack buffer object and a fence is inserted
+// after the read. Poll, normally called once per frame, checks the fence without
+// blocking and when the transfer is complete delivers a copy of the pixels to the callback.
+// A Readback supports one transfer at a time.
+// The delivered rows are padded to multiples of 4 bytes (the default PACK_ALIGNMENT).
+type Readback struct {
+	gs      *GLS                         // Reference to OpenGL state
+	pbo     uint32                       // Pixel pack buffer object
+	size    int                          // Size in bytes of the pixel pack buffer
+	length  int                          // Size in bytes of the pending transfer
+	sync    Sync                         // Fence inserted after the pending transfer
+	pending bool                         // Transfer pending flag
+	cb      func(data []byte, err error) // Callback of the pending transfer
+}
+
+// NewReadback creates and returns a pointer to a new asynchronous pixels reader.
+func (gs *GLS) NewReadback() *Readback {
+
+	rb := new(Readback)
+	rb.gs = gs
+	rb.pbo = gs.GenBuffer()
+	return rb
+}
+
+// ReadPixels starts reading the specified block of pixels from the current read
+// framebuffer. The callback is called by Poll with the pixels when the transfer is complete,
+// or with an error if the pixels could not be retrieved.
+func (rb *Readback) ReadPixels(x, y, width, height int32, format, formatType uint32, cb func(data []byte, err error)) error {
+
+	length, err := rb.begin(width, height, format, formatType)
+	if err != nil {
+		return err
+	}
+	rb.gs.ReadPixelsBuffer(x, y, width, height, format, formatType)
+	rb.end(length, cb)
+	return nil
+}
+
+// ReadTexture starts reading the image of the specified level of the specified
+// 2D texture, which must have the specified dimensions.
+// The callback is called by Poll with the pixels when the transfer is complete,
+// or with an error if the pixels could not be retrieved.
+func (rb *Readback) ReadTexture(tex uint32, level, width, height int32, format, formatType uint32, cb func(data []byte, err error)) error {
+
+	length, err := rb.begin(width, height, format, formatType)
+	if err != nil {
+		return err
+	}
+	rb.gs.BindTexture(TEXTURE_2D, tex)
+	rb.gs.GetTexImageBuffer(TEXTURE_2D, level, format, formatType)
+	rb.end(length, cb)
+	return nil
+}
+
+// Pending returns if there is a transfer in progress.
+func (rb *Readback) Pending() bool {
+
+	return rb.pending
+}
+
+// Poll checks without blocking if the pending transfer is complete and if so
+// calls its callback with a copy of the pixels.
+// If the buffer cannot be read the callback is called with a nil slice and an error.
+// Returns true if the pending transfer finished.
+func (rb *Readback) Poll() bool {
+
+	if !rb.pending {
+		return false
+	}
+	status := rb.gs.ClientWaitSync(rb.sync, 0, 0)
+	if status != ALREADY_SIGNALED && status != CONDITION_SATISFIED {
+		return false
+	}
+	rb.gs.DeleteSync(rb.sync)
+	rb.pending = false
+
+	rb.gs.BindBuffer(PIXEL_PACK_BUFFER, rb.pbo)
+	mapped := rb.gs.MapBufferRange(PIXEL_PACK_BUFFER, 0, rb.length, MAP_READ_BIT)
+	if mapped == nil {
+		rb.gs.BindBuffer(PIXEL_PACK_BUFFER, 0)
+		rb.cb(nil, fmt.Errorf("readback could not map pixel pack buffer"))
+		return true
+	}
+	data := make([]byte, rb.length)
+	copy(data, mapped)
+	var err error
+	if !rb.gs.UnmapBuffer(PIXEL_PACK_BUFFER) {
+		// The data store was corrupted while mapped
+		data = nil
+		err = fmt.Errorf("readback pixel pack buffer corrupted while mapped")
+	}
+	rb.gs.BindBuffer(PIXEL_PACK_BUFFER, 0)
+	rb.cb(data, err)
+	return true
+}
+
+// Dispose cancels the pending transfer, if any, and releases the OpenGL resources.
+func (rb *Readback) Dispose() {
+
+	if rb.pending {
+		rb.gs.DeleteSync(rb.sync)
+		rb.pending = false
+	}
+	rb.gs.DeleteBuffers(rb.pbo)
+}
+
+// begin checks that a new transfer can be started and binds the
+// pixel pack buffer with enough size for it.
+// Returns the size in bytes of the transfer.
+func (rb *Readback) begin(width, height int32, format, formatType uint32) (int, error) {
+
+	if rb.pending {
+		return 0, fmt.Errorf("readback already pending")
+	}
+	psize, err := PixelSize(format, formatType)
+	if err != nil {
+		return 0, err
+	}
+	// Rows are padded to the default PACK_ALIGNMENT of 4 bytes
+	length := int(height) * ((int(width)*psize + 3) &^ 3)
+	rb.gs.BindBuffer(PIXEL_PACK_BUFFER, rb.pbo)
+	if length > rb.size {
+		rb.gs.BufferData(PIXEL_PACK_BUFFER, length, nil, STREAM_READ)
+		rb.size = length
+	}
+	return length, nil
+}
+
+// end inserts the fence after the transfer and unbinds the pixel pack buffer.
+func (rb *Readback) end(length int, cb func(data []byte, err error)) {
+
+	rb.sync = rb.gs.FenceSync()
+	rb.gs.BindBuffer(PIXEL_PACK_BUFFER, 0)
+	rb.length = length
+	rb.cb = cb
+	rb.pending = true
+}
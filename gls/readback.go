@@ -0,0 +1,37 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gls
+
+import (
+	"fmt"
+)
+
+// PixelSize returns the size in bytes of one pixel with the specified format and type.
+func PixelSize(format, formatType uint32) (int, error) {
+
+	var components int
+	switch format {
+	case RED, RED_INTEGER, DEPTH_COMPONENT, STENCIL_INDEX:
+		components = 1
+	case RG, RG_INTEGER:
+		components = 2
+	case RGB, BGR, RGB_INTEGER:
+		components = 3
+	case RGBA, BGRA, RGBA_INTEGER:
+		components = 4
+	default:
+		return 0, fmt.Errorf("unsupported pixel format: 0x%X", format)
+	}
+	switch formatType {
+	case UNSIGNED_BYTE, BYTE:
+		return components, nil
+	case UNSIGNED_SHORT, SHORT, HALF_FLOAT:
+		return components * 2, nil
+	case UNSIGNED_INT, INT, FLOAT:
+		return components * 4, nil
+	default:
+		return 0, fmt.Errorf("unsupported pixel type: 0x%X", formatType)
+	}
+}
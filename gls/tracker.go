@@ -0,0 +1,139 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gls
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Kinds of OpenGL objects recorded by the object tracker
+const (
+	ObjBuffer      = "buffer"
+	ObjProgram     = "program"
+	ObjShader      = "shader"
+	ObjTexture     = "texture"
+	ObjVertexArray = "vertex array"
+)
+
+// LiveObject describes an OpenGL object which was created
+// while object tracking was enabled and was not yet deleted.
+type LiveObject struct {
+	Kind   string // Kind of object (ObjBuffer, ObjProgram, ...)
+	Handle uint32 // OpenGL object name
+	Stack  string // Stack trace of the object creation
+}
+
+// objKey identifies a tracked OpenGL object
+type objKey struct {
+	kind   string
+	handle uint32
+}
+
+// objTracker records the creation stack of all live tracked objects
+type objTracker struct {
+	objects map[objKey][]uintptr
+}
+
+// SetTrackObjects enables/disables the tracking of the creation and deletion of
+// OpenGL buffers, programs, shaders, textures and vertex arrays.
+// While enabled, the stack trace of each object creation is recorded, so that
+// objects which are never deleted can be reported by LiveObjects or LeakReport.
+// Only objects created after tracking is enabled are reported.
+// Disabling tracking discards all the recorded objects.
+func (gs *GLS) SetTrackObjects(enable bool) {
+
+	if !enable {
+		gs.tracker = nil
+		return
+	}
+	if gs.tracker == nil {
+		gs.tracker = &objTracker{objects: make(map[objKey][]uintptr)}
+	}
+}
+
+// TrackObjects returns if object tracking is enabled or not.
+func (gs *GLS) TrackObjects() bool {
+
+	return gs.tracker != nil
+}
+
+// LiveObjects returns the tracked objects which were not yet deleted,
+// sorted by kind and handle.
+func (gs *GLS) LiveObjects() []LiveObject {
+
+	if gs.tracker == nil {
+		return nil
+	}
+	list := make([]LiveObject, 0, len(gs.tracker.objects))
+	for key, pcs := range gs.tracker.objects {
+		list = append(list, LiveObject{Kind: key.kind, Handle: key.handle, Stack: formatStack(pcs)})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Kind != list[j].Kind {
+			return list[i].Kind < list[j].Kind
+		}
+		return list[i].Handle < list[j].Handle
+	})
+	return list
+}
+
+// LeakReport returns a text report of the tracked objects which were not yet
+// deleted, including their creation stack traces.
+// It is normally called at shutdown, after all resources should have been disposed.
+// Returns an empty string if there are no live objects.
+func (gs *GLS) LeakReport() string {
+
+	list := gs.LiveObjects()
+	if len(list) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d OpenGL objects not deleted:\n", len(list))
+	for _, obj := range list {
+		fmt.Fprintf(&sb, "%s %d created at:\n%s", obj.Kind, obj.Handle, obj.Stack)
+	}
+	return sb.String()
+}
+
+// track records the creation of the specified object if tracking is enabled.
+func (gs *GLS) track(kind string, handle uint32) {
+
+	if gs.tracker == nil {
+		return
+	}
+	pcs := make([]uintptr, 32)
+	// Skips runtime.Callers, track and the GLS creation method
+	n := runtime.Callers(3, pcs)
+	gs.tracker.objects[objKey{kind, handle}] = pcs[:n]
+}
+
+// untrack removes the specified objects from the tracker if tracking is enabled.
+func (gs *GLS) untrack(kind string, handles ...uint32) {
+
+	if gs.tracker == nil {
+		return
+	}
+	for _, h := range handles {
+		delete(gs.tracker.objects, objKey{kind, h})
+	}
+}
+
+// formatStack returns the text representation of the specified stack trace.
+func formatStack(pcs []uintptr) string {
+
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
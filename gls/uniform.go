@@ -10,18 +10,18 @@ import (
 
 // Uniform represents an OpenGL uniform.
 type Uniform struct {
-	name      string // base name
-	nameIdx   string // cached indexed name
-	handle    uint32 // program handle
-	location  int32  // last cached location
-	lastIndex int32  // last index
+	name      string   // base name
+	nameIdx   string   // cached indexed name
+	prog      *Program // program of the cached location
+	location  int32    // last cached location
+	lastIndex int32    // last index
 }
 
 // Init initializes this uniform location cache and sets its name.
 func (u *Uniform) Init(name string) {
 
 	u.name = name
-	u.handle = 0     // invalid program handle
+	u.prog = nil     // no program
 	u.location = -1  // invalid location
 	u.lastIndex = -1 // invalid index
 }
@@ -36,10 +36,9 @@ func (u *Uniform) Name() string {
 // The returned location can be -1 if not found.
 func (u *Uniform) Location(gs *GLS) int32 {
 
-	handle := gs.prog.Handle()
-	if handle != u.handle {
+	if gs.prog != u.prog {
 		u.location = gs.prog.GetUniformLocation(u.name)
-		u.handle = handle
+		u.prog = gs.prog
 	}
 	return u.location
 }
@@ -51,12 +50,11 @@ func (u *Uniform) LocationIdx(gs *GLS, idx int32) int32 {
 	if idx != u.lastIndex {
 		u.nameIdx = fmt.Sprintf("%s[%d]", u.name, idx)
 		u.lastIndex = idx
-		u.handle = 0
+		u.prog = nil
 	}
-	handle := gs.prog.Handle()
-	if handle != u.handle {
+	if gs.prog != u.prog {
 		u.location = gs.prog.GetUniformLocation(u.nameIdx)
-		u.handle = handle
+		u.prog = gs.prog
 	}
 	return u.location
 }
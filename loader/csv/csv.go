@@ -0,0 +1,126 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package csv is used to load columnar data from CSV files into
+// vertex buffer objects, mapping columns to vertex attributes.
+// The first record of the file must be a header with the column names.
+// Records are read one at a time, so large files are streamed directly
+// into the VBO buffer without holding the parsed text in memory.
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/g3n/engine/gls"
+	"github.com/g3n/engine/math32"
+)
+
+// Attrib maps one or more CSV columns to a VBO attribute.
+type Attrib struct {
+	Type    gls.AttribType // Attribute type (gls.Undefined for custom attributes)
+	Name    string         // Attribute name (only used for custom attributes)
+	Columns []string       // Names of the columns supplying each element of the attribute
+}
+
+// Decoder decodes CSV files into VBOs.
+type Decoder struct {
+	Attribs []Attrib // Column to attribute mapping
+	Comma   rune     // Field delimiter (defaults to ',')
+	Comment rune     // Optional comment character
+	Rows    int      // Number of data records decoded
+}
+
+// NewDecoder creates and returns a pointer to a new CSV decoder
+// with the specified column to attribute mapping.
+func NewDecoder(attribs ...Attrib) *Decoder {
+
+	dec := new(Decoder)
+	dec.Attribs = attribs
+	dec.Comma = ','
+	return dec
+}
+
+// DecodeFile decodes the CSV file with the specified path.
+func (dec *Decoder) DecodeFile(path string) (*gls.VBO, error) {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return dec.Decode(f)
+}
+
+// Decode decodes the CSV data from the specified reader and returns
+// an interleaved VBO with one attribute for each attribute mapping.
+func (dec *Decoder) Decode(r io.Reader) (*gls.VBO, error) {
+
+	if len(dec.Attribs) == 0 {
+		return nil, fmt.Errorf("no attributes specified")
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = dec.Comma
+	reader.Comment = dec.Comment
+	reader.ReuseRecord = true
+
+	// Read the header and map column names to record indices
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %v", err)
+	}
+	header = append([]string(nil), header...)
+	colIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		colIndex[name] = i
+	}
+
+	// Build the VBO attributes and the list of record indices in buffer order
+	vbo := gls.NewVBO(math32.NewArrayF32(0, 0))
+	var fields []int
+	for _, attr := range dec.Attribs {
+		if attr.Type == gls.Undefined {
+			vbo.AddCustomAttrib(attr.Name, int32(len(attr.Columns)))
+		} else {
+			vbo.AddAttrib(attr.Type)
+			if n := vbo.Attrib(attr.Type).NumElements; int(n) != len(attr.Columns) {
+				return nil, fmt.Errorf("attribute type %d requires %d columns, got %d", attr.Type, n, len(attr.Columns))
+			}
+		}
+		for _, col := range attr.Columns {
+			idx, ok := colIndex[col]
+			if !ok {
+				return nil, fmt.Errorf("column:%s not found", col)
+			}
+			fields = append(fields, idx)
+		}
+	}
+
+	// Read all the data records appending the mapped values to the buffer
+	buffer := vbo.Buffer()
+	dec.Rows = 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, idx := range fields {
+			v, err := strconv.ParseFloat(record[idx], 32)
+			if err != nil {
+				return nil, fmt.Errorf("record %d, column %s: %v", dec.Rows+1, header[idx], err)
+			}
+			buffer.Append(float32(v))
+		}
+		dec.Rows++
+	}
+	vbo.Update()
+	return vbo, nil
+}
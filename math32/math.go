@@ -129,3 +129,40 @@ func Pow(a, b float32) float32 {
 func Tan(v float32) float32 {
 	return float32(math.Tan(float64(v)))
 }
+
+// AlmostEqual returns whether a and b are equal within the specified absolute tolerance
+// or within the specified tolerance relative to the largest magnitude of the two values.
+// The absolute tolerance is useful for values close to zero where a relative
+// tolerance is too strict.
+func AlmostEqual(a, b, absTol, relTol float32) bool {
+
+	if a == b {
+		return true
+	}
+	diff := Abs(a - b)
+	if diff <= absTol {
+		return true
+	}
+	return diff <= relTol*Max(Abs(a), Abs(b))
+}
+
+// AlmostEqualUlps returns whether a and b are at most maxUlps representable
+// float32 values (units in the last place) apart.
+// NaN values are never equal and values with different signs are only equal if both are zero.
+func AlmostEqualUlps(a, b float32, maxUlps uint32) bool {
+
+	if IsNaN(a) || IsNaN(b) {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	if (a < 0) != (b < 0) {
+		return false
+	}
+	diff := int64(math.Float32bits(a)) - int64(math.Float32bits(b))
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= int64(maxUlps)
+}
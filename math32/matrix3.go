@@ -310,3 +310,14 @@ func (m *Matrix3) Clone() *Matrix3 {
 	cloned = *m
 	return &cloned
 }
+
+// AlmostEquals returns whether the matrix is almost equal to another matrix within the specified tolerance.
+func (m *Matrix3) AlmostEquals(other *Matrix3, tolerance float32) bool {
+
+	for i := range m {
+		if Abs(m[i]-other[i]) >= tolerance {
+			return false
+		}
+	}
+	return true
+}
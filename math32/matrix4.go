@@ -640,22 +640,31 @@ func (m *Matrix4) Decompose(position *Vector3, quaternion *Quaternion, scale *Ve
 		scale.X = -scale.X
 	}
 
-	// Scale the rotation part
-	invSX := 1 / scale.X
-	invSY := 1 / scale.Y
-	invSZ := 1 / scale.Z
-
-	matrix[0] *= invSX
-	matrix[1] *= invSX
-	matrix[2] *= invSX
-
-	matrix[4] *= invSY
-	matrix[5] *= invSY
-	matrix[6] *= invSY
-
-	matrix[8] *= invSZ
-	matrix[9] *= invSZ
-	matrix[10] *= invSZ
+	// Extract the rotation axes dividing the columns by the scale.
+	// A degenerate (zero scale) axis is rebuilt from the other two, and if two
+	// or more axes are degenerate the rotation is undefined and identity is used.
+	axes := [3]Vector3{{m[0], m[1], m[2]}, {m[4], m[5], m[6]}, {m[8], m[9], m[10]}}
+	scales := [3]float32{scale.X, scale.Y, scale.Z}
+	zero := -1
+	for i := range axes {
+		if scales[i] == 0 {
+			if zero >= 0 {
+				quaternion.SetIdentity()
+				return m
+			}
+			zero = i
+			continue
+		}
+		axes[i].MultiplyScalar(1 / scales[i])
+	}
+	if zero >= 0 {
+		axes[zero].CrossVectors(&axes[(zero+1)%3], &axes[(zero+2)%3]).Normalize()
+	}
+	for i := range axes {
+		matrix[i*4] = axes[i].X
+		matrix[i*4+1] = axes[i].Y
+		matrix[i*4+2] = axes[i].Z
+	}
 
 	quaternion.SetFromRotationMatrix(&matrix)
 
@@ -754,6 +763,17 @@ func (m *Matrix4) Clone() *Matrix4 {
 	return &cloned
 }
 
+// AlmostEquals returns whether the matrix is almost equal to another matrix within the specified tolerance.
+func (m *Matrix4) AlmostEquals(other *Matrix4, tolerance float32) bool {
+
+	for i := range m {
+		if Abs(m[i]-other[i]) >= tolerance {
+			return false
+		}
+	}
+	return true
+}
+
 // GetColumn returns the ith column.
 func (m *Matrix4) GetColumn(i int) *Vector4 {
 	return NewVector4(m[i*4], m[i*4+1], m[i*4+2], m[i*4+3])
@@ -0,0 +1,46 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package math32
+
+import "testing"
+
+// Test decomposition of matrices with negative and zero scales
+func TestMatrix4Decompose(t *testing.T) {
+
+	var rotY Quaternion
+	rotY.SetFromAxisAngle(NewVector3(0, 1, 0), Pi/3)
+
+	tests := []struct {
+		rot   Quaternion
+		scale Vector3
+		want  Quaternion
+	}{
+		{Quaternion{0, 0, 0, 1}, Vector3{0, 2, 3}, Quaternion{0, 0, 0, 1}},
+		{Quaternion{0, 0, 0, 1}, Vector3{0, 0, 0}, Quaternion{0, 0, 0, 1}},
+		{Quaternion{0, 0, 0, 1}, Vector3{-1, 2, 3}, Quaternion{0, 0, 0, 1}},
+		{rotY, Vector3{0, 2, 3}, rotY},
+		{rotY, Vector3{1, 0, 3}, rotY},
+		{rotY, Vector3{1, 2, 0}, rotY},
+	}
+	for _, test := range tests {
+		var m Matrix4
+		var pos, scale Vector3
+		var q Quaternion
+		m.Compose(&Vector3{1, 2, 3}, &test.rot, &test.scale)
+		m.Decompose(&pos, &q, &scale)
+		if !AlmostEqual(q.Length(), 1, 1e-5, 0) {
+			t.Errorf("scale %v: quaternion %v is not unit length", test.scale, q)
+		}
+		if !q.AlmostEquals(&test.want, 1e-5) {
+			t.Errorf("scale %v: got quaternion %v, want %v", test.scale, q, test.want)
+		}
+		if !scale.AlmostEquals(&test.scale, 1e-5) {
+			t.Errorf("scale %v: got scale %v", test.scale, scale)
+		}
+		if !pos.Equals(&Vector3{1, 2, 3}) {
+			t.Errorf("scale %v: got position %v", test.scale, pos)
+		}
+	}
+}
@@ -375,6 +375,16 @@ func (q *Quaternion) Equals(other *Quaternion) bool {
 	return (other.X == q.X) && (other.Y == q.Y) && (other.Z == q.Z) && (other.W == q.W)
 }
 
+// AlmostEquals returns whether the quaternion is almost equal to another quaternion within the specified tolerance.
+// The components are compared directly, so q and -q (which represent the same rotation) are not considered equal.
+func (q *Quaternion) AlmostEquals(other *Quaternion, tolerance float32) bool {
+
+	return (Abs(q.X-other.X) < tolerance) &&
+		(Abs(q.Y-other.Y) < tolerance) &&
+		(Abs(q.Z-other.Z) < tolerance) &&
+		(Abs(q.W-other.W) < tolerance)
+}
+
 // FromArray sets this quaternion's components from array starting at offset.
 // Returns pointer to this updated quaternion.
 func (q *Quaternion) FromArray(array []float32, offset int) *Quaternion {
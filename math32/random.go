@@ -0,0 +1,62 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package math32
+
+import (
+	"math/rand"
+)
+
+// The functions below generate random values using the specified random source,
+// so that sequences can be reproduced by seeding the source (e.g. rand.New(rand.NewSource(seed))).
+
+// RandomFloat returns a random number in the interval [min, max).
+func RandomFloat(r *rand.Rand, min, max float32) float32 {
+
+	return min + r.Float32()*(max-min)
+}
+
+// RandomUnitVector3 returns a pointer to a new random unit vector
+// uniformly distributed over the surface of the unit sphere.
+func RandomUnitVector3(r *rand.Rand) *Vector3 {
+
+	z := RandomFloat(r, -1, 1)
+	theta := RandomFloat(r, 0, 2*Pi)
+	s := Sqrt(1 - z*z)
+	return NewVector3(s*Cos(theta), s*Sin(theta), z)
+}
+
+// RandomInSphere returns a pointer to a new random point
+// uniformly distributed inside the specified sphere.
+func RandomInSphere(r *rand.Rand, s *Sphere) *Vector3 {
+
+	v := RandomUnitVector3(r)
+	// The cube root of the uniform value gives a uniform distribution over the volume
+	v.MultiplyScalar(s.Radius * Pow(r.Float32(), 1.0/3))
+	return v.Add(&s.Center)
+}
+
+// RandomInBox returns a pointer to a new random point
+// uniformly distributed inside the specified box.
+func RandomInBox(r *rand.Rand, b *Box3) *Vector3 {
+
+	return NewVector3(
+		RandomFloat(r, b.Min.X, b.Max.X),
+		RandomFloat(r, b.Min.Y, b.Max.Y),
+		RandomFloat(r, b.Min.Z, b.Max.Z),
+	)
+}
+
+// RandomQuaternion returns a pointer to a new random unit quaternion
+// uniformly distributed over the space of rotations.
+func RandomQuaternion(r *rand.Rand) *Quaternion {
+
+	// K. Shoemake, "Uniform random rotations", Graphics Gems III
+	u1 := r.Float32()
+	u2 := RandomFloat(r, 0, 2*Pi)
+	u3 := RandomFloat(r, 0, 2*Pi)
+	s1 := Sqrt(1 - u1)
+	s2 := Sqrt(u1)
+	return NewQuaternion(s1*Sin(u2), s1*Cos(u2), s2*Sin(u3), s2*Cos(u3))
+}
@@ -0,0 +1,248 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package renderer
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/g3n/engine/camera"
+	"github.com/g3n/engine/core"
+	"github.com/g3n/engine/gls"
+	"github.com/g3n/engine/graphic"
+	"github.com/g3n/engine/gui"
+	"github.com/g3n/engine/material"
+)
+
+// PickResult describes the graphic rendered at a picked position.
+type PickResult struct {
+	X, Y      int              // Picked position in framebuffer pixels from the top left corner
+	Graphic   graphic.IGraphic // Picked graphic or nil if no graphic was rendered at the position
+	Material  int              // Index of the picked graphic material in the graphic materials
+	Primitive int              // Index of the picked primitive (e.g. triangle) in the draw of the graphic material
+}
+
+// pickTarget identifies a graphic material rendered by the picking pass
+type pickTarget struct {
+	igr      graphic.IGraphic
+	material int
+}
+
+// Picker implements GPU picking.
+// Each pick renders the scene into an offscreen framebuffer with the "picking"
+// program, which writes the identifier of each graphic material and the index of
+// each primitive, restricted by the scissor test to the picked pixel.
+// The pixel is read back asynchronously, without stalling the pipeline, and
+// delivered to the pick callback by Poll, normally called once per frame.
+// As the picking program applies the same morph target and bone deformations
+// as the shading programs, picks are exact for rigged and morphed meshes, whose
+// deformed geometry is not known by the CPU raycaster.
+// GUI panels are not picked. One pick can be pending at a time.
+type Picker struct {
+	renderer *Renderer                       // Renderer whose shader manager builds the program
+	width    int32                           // Width of the framebuffer in pixels
+	height   int32                           // Height of the framebuffer in pixels
+	fbo      uint32                          // Offscreen framebuffer
+	tex      uint32                          // Identifiers color attachment (RG32UI)
+	rbo      uint32                          // Depth attachment
+	readback *gls.Readback                   // Asynchronous reader of the picked pixel
+	specs    ShaderSpecs                     // Program specs
+	uniID    gls.Uniform                     // Graphic material identifier uniform
+	rinfo    core.RenderInfo                 // Preallocated render info
+	targets  []pickTarget                    // Graphic materials of the pending pick by identifier - 1
+	x, y     int                             // Position of the pending pick
+	cb       func(res PickResult, err error) // Callback of the pending pick
+}
+
+// NewPicker creates and returns a pointer to a new picker whose offscreen
+// framebuffer has the specified size, which should be the size of the
+// framebuffer the scene is rendered to.
+// The renderer default shaders must have been added (see Shaman.AddDefaultShaders).
+func NewPicker(r *Renderer, width, height int32) (*Picker, error) {
+
+	p := new(Picker)
+	p.renderer = r
+	p.specs.Name = "picking"
+	p.specs.UseLights = material.UseLightNone
+	p.uniID.Init("PickID")
+
+	gs := r.gs
+	p.fbo = gs.GenFramebuffer()
+	p.tex = gs.GenTexture()
+	p.rbo = gs.GenRenderbuffer()
+	p.readback = gs.NewReadback()
+	err := p.SetSize(width, height)
+	if err != nil {
+		p.Dispose()
+		return nil, err
+	}
+	return p, nil
+}
+
+// SetSize sets the size of the offscreen framebuffer.
+// It should be called whenever the size of the framebuffer the scene is rendered to changes.
+func (p *Picker) SetSize(width, height int32) error {
+
+	gs := p.renderer.gs
+	p.width = width
+	p.height = height
+
+	gs.BindTexture(gls.TEXTURE_2D, p.tex)
+	gs.TexImage2D(gls.TEXTURE_2D, 0, gls.RG32UI, width, height, gls.RG_INTEGER, gls.UNSIGNED_INT, nil)
+	gs.TexParameteri(gls.TEXTURE_2D, gls.TEXTURE_MIN_FILTER, gls.NEAREST)
+	gs.TexParameteri(gls.TEXTURE_2D, gls.TEXTURE_MAG_FILTER, gls.NEAREST)
+	gs.BindTexture(gls.TEXTURE_2D, 0)
+	gs.BindRenderbuffer(p.rbo)
+	gs.RenderbufferStorage(gls.DEPTH_COMPONENT24, int(width), int(height))
+	gs.BindRenderbuffer(0)
+
+	gs.BindFramebuffer(p.fbo)
+	gs.FramebufferTexture2D(gls.COLOR_ATTACHMENT0, gls.TEXTURE_2D, p.tex)
+	gs.FramebufferRenderbuffer(gls.DEPTH_ATTACHMENT, p.rbo)
+	status := gs.CheckFramebufferStatus()
+	gs.BindFramebuffer(0)
+	if status != gls.FRAMEBUFFER_COMPLETE {
+		return fmt.Errorf("picking framebuffer incomplete: 0x%X", status)
+	}
+	return nil
+}
+
+// Pick starts picking the graphic of the specified scene rendered by the specified
+// camera at the specified position, in framebuffer pixels from the top left corner.
+// The callback is called by Poll with the result when the transfer of the
+// picked pixel is complete, or with an error if it could not be retrieved.
+// Returns an error if a pick is already pending or the picking program cannot be built.
+func (p *Picker) Pick(scene core.INode, cam camera.ICamera, x, y int, cb func(res PickResult, err error)) error {
+
+	if p.readback.Pending() {
+		return fmt.Errorf("pick already pending")
+	}
+	if x < 0 || y < 0 || x >= int(p.width) || y >= int(p.height) {
+		return fmt.Errorf("pick position (%d, %d) outside of the %dx%d framebuffer", x, y, p.width, p.height)
+	}
+	gs := p.renderer.gs
+
+	// Collect the graphic materials to render
+	scene.UpdateMatrixWorld()
+	cam.ViewMatrix(&p.rinfo.ViewMatrix)
+	cam.ProjMatrix(&p.rinfo.ProjMatrix)
+	p.targets = p.targets[:0]
+	p.collect(scene)
+
+	// Restrict rendering to the picked pixel, whose row is counted from the bottom
+	vx, vy, vwidth, vheight := gs.GetViewport()
+	px := int32(x)
+	py := p.height - 1 - int32(y)
+	gs.BindFramebuffer(p.fbo)
+	gs.Viewport(0, 0, p.width, p.height)
+	gs.Enable(gls.SCISSOR_TEST)
+	gs.Scissor(px, py, 1, 1)
+	gs.ClearBufferuiv(gls.COLOR, 0, [4]uint32{})
+	gs.DepthMask(true)
+	gs.Clear(gls.DEPTH_BUFFER_BIT)
+
+	err := p.render()
+	if err == nil {
+		err = p.readback.ReadPixels(px, py, 1, 1, gls.RG_INTEGER, gls.UNSIGNED_INT, p.deliver)
+	}
+	gs.Disable(gls.SCISSOR_TEST)
+	gs.BindFramebuffer(0)
+	gs.Viewport(vx, vy, vwidth, vheight)
+	if err != nil {
+		return err
+	}
+	p.x = x
+	p.y = y
+	p.cb = cb
+	return nil
+}
+
+// Pending returns if there is a pick in progress.
+func (p *Picker) Pending() bool {
+
+	return p.readback.Pending()
+}
+
+// Poll checks without blocking if the pending pick is complete and if so
+// calls its callback with the result.
+// Returns true if the pending pick finished.
+func (p *Picker) Poll() bool {
+
+	return p.readback.Poll()
+}
+
+// Dispose cancels the pending pick, if any, and releases the OpenGL resources
+// of this picker. The picking program is kept by the shader manager.
+func (p *Picker) Dispose() {
+
+	gs := p.renderer.gs
+	p.readback.Dispose()
+	gs.DeleteFramebuffers(p.fbo)
+	gs.DeleteRenderbuffers(p.rbo)
+	gs.DeleteTextures(p.tex)
+	p.targets = nil
+}
+
+// collect appends the graphic materials of the visible graphics of the
+// specified node and its descendants to the pick targets.
+func (p *Picker) collect(inode core.INode) {
+
+	if !inode.Visible() {
+		return
+	}
+	if _, ok := inode.(gui.IPanel); ok {
+		return
+	}
+	if igr, ok := inode.(graphic.IGraphic); ok && igr.Renderable() {
+		for i := range igr.GetGraphic().Materials() {
+			p.targets = append(p.targets, pickTarget{igr, i})
+		}
+	}
+	for _, ichild := range inode.Children() {
+		p.collect(ichild)
+	}
+}
+
+// render renders the pick targets with the picking program,
+// identifying each graphic material by its index in the targets plus one.
+func (p *Picker) render() error {
+
+	gs := p.renderer.gs
+	for i, t := range p.targets {
+		gr := t.igr.GetGraphic()
+		gr.CalculateMatrices(gs, &p.rinfo)
+
+		// Only the geometry and graphic defines select deformations
+		p.specs.Defines = *gls.NewShaderDefines()
+		p.specs.Defines.Add(&t.igr.GetGeometry().ShaderDefines)
+		p.specs.Defines.Add(&gr.ShaderDefines)
+		_, err := p.renderer.SetProgram(&p.specs)
+		if err != nil {
+			return err
+		}
+		gs.Uniform1ui(p.uniID.Location(gs), uint32(i+1))
+		gr.Materials()[t.material].Render(gs, &p.rinfo)
+	}
+	return nil
+}
+
+// deliver decodes the picked pixel and calls the callback of the pending pick.
+func (p *Picker) deliver(data []byte, err error) {
+
+	res := PickResult{X: p.x, Y: p.y}
+	if err != nil {
+		p.cb(res, err)
+		return
+	}
+	// The identifiers are in the native byte order of the supported platforms
+	id := binary.LittleEndian.Uint32(data[0:])
+	if id > 0 && int(id) <= len(p.targets) {
+		t := p.targets[id-1]
+		res.Graphic = t.igr
+		res.Material = t.material
+		res.Primitive = int(binary.LittleEndian.Uint32(data[4:]))
+	}
+	p.cb(res, nil)
+}
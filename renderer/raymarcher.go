@@ -0,0 +1,106 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package renderer
+
+import (
+	"github.com/g3n/engine/camera"
+	"github.com/g3n/engine/gls"
+	"github.com/g3n/engine/material"
+	"github.com/g3n/engine/math32"
+)
+
+// Raymarcher is a full screen render pass which raymarches a scene described by a
+// signed distance field (SDF) written in GLSL.
+// The SDF source is registered as an include chunk which must define the functions:
+//
+//	float sdfScene(vec3 p)                  // signed distance from p to the scene
+//	vec4  sdfShade(vec3 p, vec3 n, vec3 rd) // color of the surface point p with normal n hit by a ray with direction rd
+//
+// with all positions in world coordinates (see the default "raymarch" program).
+// The pass writes the depth of the surface hit by each ray, so when it is rendered
+// after the scene into the same framebuffer (the default framebuffer or a
+// Postprocessor framebuffer) it is composited with the rasterized geometry by the depth test.
+type Raymarcher struct {
+	MaxSteps    int     // Maximum number of steps per ray
+	MaxDistance float32 // Maximum distance travelled by a ray
+	Epsilon     float32 // Distance from the surface considered a hit
+
+	renderer       *Renderer      // Renderer whose shader manager builds the program
+	specs          ShaderSpecs    // Program specs
+	vao            uint32         // Empty vertex array object for the full screen triangle
+	uniProjView    gls.Uniform    // Projection * view matrix uniform
+	uniInvProjView gls.Uniform    // Inverse projection * view matrix uniform
+	uniParams      gls.Uniform    // Marching parameters uniform
+	projView       math32.Matrix4 // Preallocated projection * view matrix
+	invProjView    math32.Matrix4 // Preallocated inverse projection * view matrix
+}
+
+// NewRaymarcher creates and returns a pointer to a new raymarching pass with the
+// specified name, which renders the scene described by the specified SDF source.
+// The SDF source is added to the renderer shader manager as the include chunk
+// "raymarch_<name>_sdf", so it does not replace other chunks, and the pass
+// program is named "raymarch_<name>".
+// The renderer default shaders must have been added (see Shaman.AddDefaultShaders).
+func NewRaymarcher(r *Renderer, name, sdf string) *Raymarcher {
+
+	rm := new(Raymarcher)
+	rm.MaxSteps = 128
+	rm.MaxDistance = 1000
+	rm.Epsilon = 0.001
+	rm.renderer = r
+
+	progName := "raymarch_" + name
+	chunkName := progName + "_sdf"
+	r.AddChunk(chunkName, sdf)
+	r.AddShader(progName+"_fragment", "precision highp float;\n\n#include <"+chunkName+">\n#include <raymarch>\n")
+	r.AddProgram(progName, "raymarch_vertex", progName+"_fragment")
+	rm.specs.Name = progName
+	rm.specs.ShaderUnique = true
+	rm.specs.UseLights = material.UseLightNone
+
+	rm.vao = r.gs.GenVertexArray()
+	rm.uniProjView.Init("ProjView")
+	rm.uniInvProjView.Init("InvProjView")
+	rm.uniParams.Init("RaymarchParams")
+	return rm
+}
+
+// Render raymarches the scene as seen by the specified camera into the
+// currently bound framebuffer, testing and writing the depth buffer.
+func (rm *Raymarcher) Render(icam camera.ICamera) error {
+
+	gs := rm.renderer.gs
+	_, err := rm.renderer.SetProgram(&rm.specs)
+	if err != nil {
+		return err
+	}
+
+	// Transfer camera matrices
+	var view, proj math32.Matrix4
+	icam.ViewMatrix(&view)
+	icam.ProjMatrix(&proj)
+	rm.projView.MultiplyMatrices(&proj, &view)
+	err = rm.invProjView.GetInverse(&rm.projView)
+	if err != nil {
+		return err
+	}
+	gs.UniformMatrix4fv(rm.uniProjView.Location(gs), 1, false, &rm.projView[0])
+	gs.UniformMatrix4fv(rm.uniInvProjView.Location(gs), 1, false, &rm.invProjView[0])
+	gs.Uniform4f(rm.uniParams.Location(gs), float32(rm.MaxSteps), rm.MaxDistance, rm.Epsilon, 0)
+
+	// Draw the full screen triangle
+	gs.Enable(gls.DEPTH_TEST)
+	gs.DepthMask(true)
+	gs.BindVertexArray(rm.vao)
+	gs.DrawArrays(gls.TRIANGLES, 0, 3)
+	return nil
+}
+
+// Dispose releases the OpenGL resources and the program of this pass.
+func (rm *Raymarcher) Dispose() {
+
+	rm.renderer.gs.DeleteVertexArrays(rm.vao)
+	rm.renderer.RemoveProgram(rm.specs.Name)
+}
@@ -7,6 +7,7 @@ package renderer
 
 import (
 	"sort"
+	"time"
 
 	"github.com/g3n/engine/camera"
 	"github.com/g3n/engine/core"
@@ -30,6 +31,9 @@ type Renderer struct {
 	specs       ShaderSpecs     // Preallocated Shader specs
 	sortObjects bool            // Flag indicating whether objects should be sorted before rendering
 	stats       Stats           // Renderer statistics
+	preHooks    []RenderHook    // Functions called before rendering each frame
+	postHooks   []RenderHook    // Functions called after rendering each frame
+	lastRender  time.Time       // Start time of the last call to Render
 
 	// Populated each frame
 	ambLights    []*light.Ambient           // Ambient lights in the scene
@@ -53,6 +57,10 @@ type Stats struct {
 	Others      int // Number of other objects rendered
 }
 
+// RenderHook is the type of the functions called before and after rendering a frame.
+// It receives the OpenGL state and the elapsed time since the previous frame was rendered.
+type RenderHook func(gs *gls.GLS, dt time.Duration)
+
 // NewRenderer creates and returns a pointer to a new Renderer.
 func NewRenderer(gs *gls.GLS) *Renderer {
 
@@ -95,9 +103,41 @@ func (r *Renderer) ObjectSorting() bool {
 	return r.sortObjects
 }
 
+// AddPreRenderHook adds a function to be called at the start of each Render,
+// before the scene is traversed. Hooks are called in the order they were added.
+func (r *Renderer) AddPreRenderHook(hook RenderHook) {
+
+	r.preHooks = append(r.preHooks, hook)
+}
+
+// AddPostRenderHook adds a function to be called at the end of each successful Render,
+// after all the scene objects were rendered. Hooks are called in the order they were added.
+func (r *Renderer) AddPostRenderHook(hook RenderHook) {
+
+	r.postHooks = append(r.postHooks, hook)
+}
+
+// ClearRenderHooks removes all the pre and post render hooks.
+func (r *Renderer) ClearRenderHooks() {
+
+	r.preHooks = nil
+	r.postHooks = nil
+}
+
 // Render renders the specified scene using the specified camera. Returns an an error.
 func (r *Renderer) Render(scene core.INode, cam camera.ICamera) error {
 
+	// Calculates the elapsed time since the last frame and calls the pre render hooks
+	now := time.Now()
+	var dt time.Duration
+	if !r.lastRender.IsZero() {
+		dt = now.Sub(r.lastRender)
+	}
+	r.lastRender = now
+	for _, hook := range r.preHooks {
+		hook(r.gs, dt)
+	}
+
 	// Updates world matrices of all scene nodes
 	scene.UpdateMatrixWorld()
 
@@ -204,6 +244,11 @@ func (r *Renderer) Render(scene core.INode, cam camera.ICamera) error {
 	// TODO enable color mask, stencil mask?
 	// TODO clear the buffers for the user, and set the appropriate masks to true before clearing
 
+	// Call the post render hooks
+	for _, hook := range r.postHooks {
+		hook(r.gs, dt)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,67 @@
+//
+// Raymarching fragment shader main.
+// The scene must be described before including this chunk by the functions:
+//   float sdfScene(vec3 p)                 - signed distance from p to the scene
+//   vec4  sdfShade(vec3 p, vec3 n, vec3 rd) - color of the surface point p with normal n
+// All positions are in world coordinates.
+//
+
+// Camera uniforms
+uniform mat4 ProjView;        // projection * view matrix
+uniform mat4 InvProjView;     // inverse of projection * view matrix
+
+// Marching parameters
+uniform vec4 RaymarchParams;
+#define MaxSteps        int(RaymarchParams.x)  // maximum number of steps per ray
+#define MaxDistance     RaymarchParams.y       // maximum distance travelled by a ray
+#define SurfaceEpsilon  RaymarchParams.z       // distance considered a surface hit
+
+// Input from vertex shader
+in vec2 NDC;
+
+// Final fragment color
+out vec4 FragColor;
+
+// Returns the surface normal at p from the gradient of the distance field
+vec3 sdfNormal(vec3 p) {
+
+    vec2 e = vec2(SurfaceEpsilon, 0.0);
+    return normalize(vec3(
+        sdfScene(p + e.xyy) - sdfScene(p - e.xyy),
+        sdfScene(p + e.yxy) - sdfScene(p - e.yxy),
+        sdfScene(p + e.yyx) - sdfScene(p - e.yyx)
+    ));
+}
+
+void main() {
+
+    // Unproject the fragment on the near and far planes to build the ray
+    vec4 near = InvProjView * vec4(NDC, -1.0, 1.0);
+    vec4 far = InvProjView * vec4(NDC, 1.0, 1.0);
+    vec3 ro = near.xyz / near.w;
+    vec3 rd = normalize(far.xyz / far.w - ro);
+
+    // March the ray through the distance field
+    float t = 0.0;
+    bool hit = false;
+    for (int i = 0; i < MaxSteps; i++) {
+        float d = sdfScene(ro + rd * t);
+        if (d < SurfaceEpsilon) {
+            hit = true;
+            break;
+        }
+        t += d;
+        if (t > MaxDistance) {
+            break;
+        }
+    }
+    if (!hit) {
+        discard;
+    }
+
+    // Shade the hit point and write its depth so it is tested against rasterized geometry
+    vec3 p = ro + rd * t;
+    FragColor = sdfShade(p, sdfNormal(p), rd);
+    vec4 clip = ProjView * vec4(p, 1.0);
+    gl_FragDepth = (gl_DepthRange.diff * clip.z / clip.w + gl_DepthRange.near + gl_DepthRange.far) * 0.5;
+}
@@ -0,0 +1,12 @@
+precision highp float;
+
+// Identifier of the graphic material being rendered
+uniform uint PickID;
+
+// Output graphic material identifier and primitive index
+out uvec2 FragID;
+
+void main() {
+
+    FragID = uvec2(PickID, uint(gl_PrimitiveID));
+}
@@ -0,0 +1,20 @@
+#include <attributes>
+
+// Model uniforms
+uniform mat4 MVP;
+
+#include <morphtarget_vertex_declaration>
+#include <bones_vertex_declaration>
+
+void main() {
+
+    // Apply the same deformations as the shading programs
+    vec3 vPosition = VertexPosition;
+    vec3 vNormal = VertexNormal;
+    mat4 finalWorld = mat4(1.0);
+    #include <morphtarget_vertex>
+    #include <bones_vertex>
+
+    // Output projected and transformed vertex position
+    gl_Position = MVP * finalWorld * vec4(vPosition, 1.0);
+}
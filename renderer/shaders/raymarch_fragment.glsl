@@ -0,0 +1,18 @@
+precision highp float;
+
+// Default raymarching scene: a unit sphere at the origin.
+// Raymarching passes replace these functions by the ones in their SDF chunk.
+
+// Returns the signed distance from the point to the closest surface of the scene
+float sdfScene(vec3 p) {
+
+    return length(p) - 1.0;
+}
+
+// Returns the color of the surface point with the specified normal hit by the ray with direction rd
+vec4 sdfShade(vec3 p, vec3 n, vec3 rd) {
+
+    return vec4(vec3(0.2 + 0.8 * max(dot(n, -rd), 0.0)), 1.0);
+}
+
+#include <raymarch>
@@ -0,0 +1,11 @@
+// Full screen triangle generated from the vertex index (no vertex attributes)
+
+// Output normalized device coordinates for fragment shader
+out vec2 NDC;
+
+void main() {
+
+    vec2 pos = vec2(float((gl_VertexID & 1) << 2) - 1.0, float((gl_VertexID & 2) << 1) - 1.0);
+    NDC = pos;
+    gl_Position = vec4(pos, 0.0, 1.0);
+}
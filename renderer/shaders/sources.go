@@ -1126,6 +1126,144 @@ void main() {
 }
 `
 
+const include_raymarch_source = `//
+// Raymarching fragment shader main.
+// The scene must be described before including this chunk by the functions:
+//   float sdfScene(vec3 p)                 - signed distance from p to the scene
+//   vec4  sdfShade(vec3 p, vec3 n, vec3 rd) - color of the surface point p with normal n
+// All positions are in world coordinates.
+//
+
+// Camera uniforms
+uniform mat4 ProjView;        // projection * view matrix
+uniform mat4 InvProjView;     // inverse of projection * view matrix
+
+// Marching parameters
+uniform vec4 RaymarchParams;
+#define MaxSteps        int(RaymarchParams.x)  // maximum number of steps per ray
+#define MaxDistance     RaymarchParams.y       // maximum distance travelled by a ray
+#define SurfaceEpsilon  RaymarchParams.z       // distance considered a surface hit
+
+// Input from vertex shader
+in vec2 NDC;
+
+// Final fragment color
+out vec4 FragColor;
+
+// Returns the surface normal at p from the gradient of the distance field
+vec3 sdfNormal(vec3 p) {
+
+    vec2 e = vec2(SurfaceEpsilon, 0.0);
+    return normalize(vec3(
+        sdfScene(p + e.xyy) - sdfScene(p - e.xyy),
+        sdfScene(p + e.yxy) - sdfScene(p - e.yxy),
+        sdfScene(p + e.yyx) - sdfScene(p - e.yyx)
+    ));
+}
+
+void main() {
+
+    // Unproject the fragment on the near and far planes to build the ray
+    vec4 near = InvProjView * vec4(NDC, -1.0, 1.0);
+    vec4 far = InvProjView * vec4(NDC, 1.0, 1.0);
+    vec3 ro = near.xyz / near.w;
+    vec3 rd = normalize(far.xyz / far.w - ro);
+
+    // March the ray through the distance field
+    float t = 0.0;
+    bool hit = false;
+    for (int i = 0; i < MaxSteps; i++) {
+        float d = sdfScene(ro + rd * t);
+        if (d < SurfaceEpsilon) {
+            hit = true;
+            break;
+        }
+        t += d;
+        if (t > MaxDistance) {
+            break;
+        }
+    }
+    if (!hit) {
+        discard;
+    }
+
+    // Shade the hit point and write its depth so it is tested against rasterized geometry
+    vec3 p = ro + rd * t;
+    FragColor = sdfShade(p, sdfNormal(p), rd);
+    vec4 clip = ProjView * vec4(p, 1.0);
+    gl_FragDepth = (gl_DepthRange.diff * clip.z / clip.w + gl_DepthRange.near + gl_DepthRange.far) * 0.5;
+}
+`
+
+const raymarch_vertex_source = `// Full screen triangle generated from the vertex index (no vertex attributes)
+
+// Output normalized device coordinates for fragment shader
+out vec2 NDC;
+
+void main() {
+
+    vec2 pos = vec2(float((gl_VertexID & 1) << 2) - 1.0, float((gl_VertexID & 2) << 1) - 1.0);
+    NDC = pos;
+    gl_Position = vec4(pos, 0.0, 1.0);
+}
+`
+
+const raymarch_fragment_source = `precision highp float;
+
+// Default raymarching scene: a unit sphere at the origin.
+// Raymarching passes replace these functions by the ones in their SDF chunk.
+
+// Returns the signed distance from the point to the closest surface of the scene
+float sdfScene(vec3 p) {
+
+    return length(p) - 1.0;
+}
+
+// Returns the color of the surface point with the specified normal hit by the ray with direction rd
+vec4 sdfShade(vec3 p, vec3 n, vec3 rd) {
+
+    return vec4(vec3(0.2 + 0.8 * max(dot(n, -rd), 0.0)), 1.0);
+}
+
+#include <raymarch>
+`
+
+const picking_vertex_source = `#include <attributes>
+
+// Model uniforms
+uniform mat4 MVP;
+
+#include <morphtarget_vertex_declaration>
+#include <bones_vertex_declaration>
+
+void main() {
+
+    // Apply the same deformations as the shading programs
+    vec3 vPosition = VertexPosition;
+    vec3 vNormal = VertexNormal;
+    mat4 finalWorld = mat4(1.0);
+    #include <morphtarget_vertex>
+    #include <bones_vertex>
+
+    // Output projected and transformed vertex position
+    gl_Position = MVP * finalWorld * vec4(vPosition, 1.0);
+}
+`
+
+const picking_fragment_source = `precision highp float;
+
+// Identifier of the graphic material being rendered
+uniform uint PickID;
+
+// Output graphic material identifier and primitive index
+out uvec2 FragID;
+
+void main() {
+
+    FragID = uvec2(PickID, uint(gl_PrimitiveID));
+}
+`
+
 // Maps include name with its source code
 var includeMap = map[string]string{
 
@@ -1139,6 +1277,7 @@ var includeMap = map[string]string{
 	"material":                        include_material_source,
 	"lights":                          include_lights_source,
 	"bones_vertex_declaration":        include_bones_vertex_declaration_source,
+	"raymarch":                        include_raymarch_source,
 }
 
 // Maps shader name with its source code
@@ -1154,6 +1293,10 @@ var shaderMap = map[string]string{
 	"panel_vertex":      panel_vertex_source,
 	"basic_fragment":    basic_fragment_source,
 	"panel_fragment":    panel_fragment_source,
+	"raymarch_vertex":   raymarch_vertex_source,
+	"raymarch_fragment": raymarch_fragment_source,
+	"picking_vertex":    picking_vertex_source,
+	"picking_fragment":  picking_fragment_source,
 }
 
 // Maps program name with Proginfo struct with shaders names
@@ -1162,6 +1305,8 @@ var programMap = map[string]ProgramInfo{
 	"basic":    {"basic_vertex", "basic_fragment", ""},
 	"panel":    {"panel_vertex", "panel_fragment", ""},
 	"physical": {"physical_vertex", "physical_fragment", ""},
+	"picking":  {"picking_vertex", "picking_fragment", ""},
 	"point":    {"point_vertex", "point_fragment", ""},
+	"raymarch": {"raymarch_vertex", "raymarch_fragment", ""},
 	"standard": {"standard_vertex", "standard_fragment", ""},
 }
@@ -5,12 +5,15 @@
 package renderer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"strconv"
 
+	"github.com/g3n/engine/core"
 	"github.com/g3n/engine/gls"
 	"github.com/g3n/engine/material"
 	"github.com/g3n/engine/renderer/shaders"
@@ -21,6 +24,17 @@ var rexInclude *regexp.Regexp
 
 const indexParameter = "{i}"
 
+// Errors returned by the shader manager.
+// They are wrapped with the name of the missing or offending item,
+// so they should be checked with errors.Is.
+var (
+	ErrProgramNotFound = errors.New("program not found")
+	ErrShaderNotFound  = errors.New("shader not found")
+	ErrIncludeNotFound = errors.New("include not found")
+	ErrIncludeCycle    = errors.New("include cycle")
+	ErrIncludeQuantity = errors.New("invalid include quantity")
+)
+
 func init() {
 
 	rexInclude = regexp.MustCompile(`#include\s+<(.*)>\s*(?:\[(.*)]|)`)
@@ -29,7 +43,8 @@ func init() {
 // ShaderSpecs describes the specification of a compiled shader program
 type ShaderSpecs struct {
 	Name             string             // Shader name
-	Version          string             // GLSL version
+	Version          string             // GLSL version (uses GLSL_VERSION if empty)
+	Extensions       []string           // GLSL extensions enabled with "#extension <name> : enable"
 	ShaderUnique     bool               // indicates if shader is independent of lights and textures
 	UseLights        material.UseLights // Bitmask indicating which lights to consider
 	AmbientLightsMax int                // Current number of ambient lights
@@ -40,20 +55,48 @@ type ShaderSpecs struct {
 	Defines          gls.ShaderDefines  // Additional shader defines
 }
 
+// Shader manager events.
+// The event parameter is a pointer to a ProgramEvent.
+const (
+	OnProgramCompiled = "renderer.OnProgramCompiled" // A new program was compiled
+	OnCompileError    = "renderer.OnCompileError"    // A program failed to compile or link
+	OnProgramSwitched = "renderer.OnProgramSwitched" // The current program was changed
+)
+
+// ProgramEvent describes a shader manager event.
+type ProgramEvent struct {
+	Specs   *ShaderSpecs // Specs of the program
+	Program *gls.Program // Program object (nil for OnCompileError)
+	Err     error        // Compile or link error (only for OnCompileError)
+}
+
+// CompiledProgram describes a compiled shader program variant.
+type CompiledProgram struct {
+	Specs   ShaderSpecs // Copy of the specs the program was compiled for (name, defines, light counts)
+	Handle  uint32      // OpenGL program handle
+	Current bool        // Indicates if this is the current program
+	LastUse uint64      // Value of the use counter when the program was last activated or prewarmed
+}
+
 // ProgSpecs represents a compiled shader program along with its specs
 type ProgSpecs struct {
 	program *gls.Program // program object
 	specs   ShaderSpecs  // associated specs
+	lastUse uint64       // value of the use counter when the program was last activated
 }
 
 // Shaman is the shader manager
 type Shaman struct {
-	gs       *gls.GLS                       // Reference to OpenGL state
-	includes map[string]string              // include files sources
-	shadersm map[string]string              // maps shader name to its template
-	proginfo map[string]shaders.ProgramInfo // maps name of the program to ProgramInfo
-	programs []ProgSpecs                    // list of compiled programs with specs
-	specs    ShaderSpecs                    // Current shader specs
+	core.Dispatcher                                // Embedded event dispatcher
+	gs              *gls.GLS                       // Reference to OpenGL state
+	includes        map[string]string              // include files sources
+	shadersm        map[string]string              // maps shader name to its template
+	proginfo        map[string]shaders.ProgramInfo // maps name of the program to ProgramInfo
+	programs        []ProgSpecs                    // list of compiled programs with specs
+	specs           ShaderSpecs                    // Current shader specs
+	maxProgs        int                            // maximum number of compiled programs (0 = unlimited)
+	useCount        uint64                         // counter incremented at each program activation
+	ev              ProgramEvent                   // Preallocated event
 }
 
 // NewShaman creates and returns a pointer to a new shader manager
@@ -67,6 +110,7 @@ func NewShaman(gs *gls.GLS) *Shaman {
 // Init initializes the shader manager
 func (sm *Shaman) Init(gs *gls.GLS) {
 
+	sm.Dispatcher.Initialize()
 	sm.gs = gs
 	sm.includes = make(map[string]string)
 	sm.shadersm = make(map[string]string)
@@ -127,19 +171,7 @@ func (sm *Shaman) SetProgram(s *ShaderSpecs) (bool, error) {
 
 	// Checks material use lights bit mask
 	var specs ShaderSpecs
-	specs.copy(s)
-	if (specs.UseLights & material.UseLightAmbient) == 0 {
-		specs.AmbientLightsMax = 0
-	}
-	if (specs.UseLights & material.UseLightDirectional) == 0 {
-		specs.DirLightsMax = 0
-	}
-	if (specs.UseLights & material.UseLightPoint) == 0 {
-		specs.PointLightsMax = 0
-	}
-	if (specs.UseLights & material.UseLightSpot) == 0 {
-		specs.SpotLightsMax = 0
-	}
+	specs.prepare(s)
 
 	// If current shader specs are the same as the specified specs, nothing to do.
 	if sm.specs.equals(&specs) {
@@ -147,16 +179,17 @@ func (sm *Shaman) SetProgram(s *ShaderSpecs) (bool, error) {
 	}
 
 	// Search for compiled program with the specified specs
-	for _, pinfo := range sm.programs {
-		if pinfo.specs.equals(&specs) {
-			sm.gs.UseProgram(pinfo.program)
-			sm.specs = specs
-			return true, nil
-		}
+	if idx := sm.findProgram(&specs); idx >= 0 {
+		sm.useCount++
+		sm.programs[idx].lastUse = sm.useCount
+		sm.gs.UseProgram(sm.programs[idx].program)
+		sm.specs = specs
+		sm.dispatch(OnProgramSwitched, &sm.specs, sm.programs[idx].program, nil)
+		return true, nil
 	}
 
 	// Generates new program with the specified specs
-	prog, err := sm.GenProgram(&specs)
+	prog, err := sm.compile(&specs)
 	if err != nil {
 		return false, err
 	}
@@ -164,18 +197,205 @@ func (sm *Shaman) SetProgram(s *ShaderSpecs) (bool, error) {
 
 	// Save specs as current specs, adds new program to the list and activates the program
 	sm.specs = specs
-	sm.programs = append(sm.programs, ProgSpecs{prog, specs})
+	sm.useCount++
+	sm.programs = append(sm.programs, ProgSpecs{prog, specs, sm.useCount})
 	sm.gs.UseProgram(prog)
+	sm.evict()
+	sm.dispatch(OnProgramSwitched, &sm.specs, prog, nil)
 	return true, nil
 }
 
+// Prewarm generates ahead of time the shader programs for all the specified specs
+// which were not compiled yet, so that the first call to SetProgram with any of
+// them doesn't need to compile a program. The current program is not changed.
+func (sm *Shaman) Prewarm(specs ...*ShaderSpecs) error {
+
+	return sm.PrewarmContext(context.Background(), specs...)
+}
+
+// PrewarmContext is like Prewarm but stops before compiling the next program
+// when the specified context is cancelled or its deadline is exceeded, returning
+// the context error. The programs compiled before that are kept, so a frame time
+// budget can be enforced by calling it once per frame with a deadline until it returns nil.
+// Prewarmed programs count as used, so they are evicted after older programs.
+// Returns an error if more programs are specified than the maximum number of programs.
+func (sm *Shaman) PrewarmContext(ctx context.Context, specs ...*ShaderSpecs) error {
+
+	// The current program is never evicted, so it counts against the maximum
+	// unless it is one of the prewarmed programs.
+	if sm.maxProgs > 0 {
+		count := len(specs)
+		current := sm.findProgram(&sm.specs) >= 0
+		for _, s := range specs {
+			var spec ShaderSpecs
+			spec.prepare(s)
+			if spec.equals(&sm.specs) {
+				current = false
+				break
+			}
+		}
+		if current {
+			count++
+		}
+		if count > sm.maxProgs {
+			return fmt.Errorf("prewarm of %d programs exceeds the maximum of %d programs", count, sm.maxProgs)
+		}
+	}
+	defer sm.evict()
+	for _, s := range specs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var spec ShaderSpecs
+		spec.prepare(s)
+		sm.useCount++
+		if idx := sm.findProgram(&spec); idx >= 0 {
+			sm.programs[idx].lastUse = sm.useCount
+			continue
+		}
+		prog, err := sm.compile(&spec)
+		if err != nil {
+			return err
+		}
+		log.Debug("Prewarmed shader:%v", spec.Name)
+		sm.programs = append(sm.programs, ProgSpecs{prog, spec, sm.useCount})
+	}
+	return nil
+}
+
+// RemoveProgram deletes all the compiled variants of the program with the specified name.
+// Returns the number of compiled programs removed.
+func (sm *Shaman) RemoveProgram(name string) int {
+
+	removed := 0
+	i := 0
+	for _, pinfo := range sm.programs {
+		if pinfo.specs.Name == name {
+			pinfo.program.Dispose()
+			removed++
+		} else {
+			sm.programs[i] = pinfo
+			i++
+		}
+	}
+	sm.programs = sm.programs[:i]
+	if sm.specs.Name == name {
+		sm.specs = ShaderSpecs{}
+	}
+	return removed
+}
+
+// SetMaxPrograms sets the maximum number of compiled programs kept by this shader manager.
+// When the maximum is exceeded the least recently used programs are deleted.
+// The default value of 0 means no limit.
+func (sm *Shaman) SetMaxPrograms(max int) {
+
+	sm.maxProgs = max
+	sm.evict()
+}
+
+// MaxPrograms returns the maximum number of compiled programs kept by this shader manager.
+func (sm *Shaman) MaxPrograms() int {
+
+	return sm.maxProgs
+}
+
+// ProgramCount returns the current number of compiled programs.
+func (sm *Shaman) ProgramCount() int {
+
+	return len(sm.programs)
+}
+
+// Programs returns the descriptions of all the compiled program variants,
+// in the order they were compiled.
+func (sm *Shaman) Programs() []CompiledProgram {
+
+	list := make([]CompiledProgram, len(sm.programs))
+	for i := range sm.programs {
+		pinfo := &sm.programs[i]
+		list[i].Specs.copy(&pinfo.specs)
+		list[i].Handle = pinfo.program.Handle()
+		list[i].Current = pinfo.specs.equals(&sm.specs)
+		list[i].LastUse = pinfo.lastUse
+	}
+	return list
+}
+
+// Dispose deletes all the compiled programs of this shader manager.
+// Registered shaders, chunks and programs are kept and
+// programs are compiled again when requested.
+func (sm *Shaman) Dispose() {
+
+	for _, pinfo := range sm.programs {
+		pinfo.program.Dispose()
+	}
+	sm.programs = sm.programs[:0]
+	sm.specs = ShaderSpecs{}
+}
+
+// findProgram returns the index of the compiled program with the specified specs or -1 if not found.
+func (sm *Shaman) findProgram(specs *ShaderSpecs) int {
+
+	for i := range sm.programs {
+		if sm.programs[i].specs.equals(specs) {
+			return i
+		}
+	}
+	return -1
+}
+
+// evict deletes the least recently used programs, except the current one,
+// while the number of compiled programs exceeds the maximum.
+func (sm *Shaman) evict() {
+
+	for sm.maxProgs > 0 && len(sm.programs) > sm.maxProgs {
+		lru := -1
+		for i := range sm.programs {
+			if sm.programs[i].specs.equals(&sm.specs) {
+				continue
+			}
+			if lru < 0 || sm.programs[i].lastUse < sm.programs[lru].lastUse {
+				lru = i
+			}
+		}
+		if lru < 0 {
+			return
+		}
+		log.Debug("Evicted shader:%v", sm.programs[lru].specs.Name)
+		sm.programs[lru].program.Dispose()
+		sm.programs = append(sm.programs[:lru], sm.programs[lru+1:]...)
+	}
+}
+
+// compile generates a shader program from the specified specs
+// and dispatches OnProgramCompiled or OnCompileError.
+func (sm *Shaman) compile(specs *ShaderSpecs) (*gls.Program, error) {
+
+	prog, err := sm.GenProgram(specs)
+	if err != nil {
+		sm.dispatch(OnCompileError, specs, nil, err)
+		return nil, err
+	}
+	sm.dispatch(OnProgramCompiled, specs, prog, nil)
+	return prog, nil
+}
+
+// dispatch dispatches the specified event using the preallocated event parameter.
+func (sm *Shaman) dispatch(evname string, specs *ShaderSpecs, prog *gls.Program, err error) {
+
+	sm.ev.Specs = specs
+	sm.ev.Program = prog
+	sm.ev.Err = err
+	sm.Dispatch(evname, &sm.ev)
+}
+
 // GenProgram generates shader program from the specified specs
 func (sm *Shaman) GenProgram(specs *ShaderSpecs) (*gls.Program, error) {
 
 	// Get info for the specified shader program
 	progInfo, ok := sm.proginfo[specs.Name]
 	if !ok {
-		return nil, fmt.Errorf("Program:%s not found", specs.Name)
+		return nil, fmt.Errorf("%w: %s", ErrProgramNotFound, specs.Name)
 	}
 
 	// Sets the defines map
@@ -194,10 +414,10 @@ func (sm *Shaman) GenProgram(specs *ShaderSpecs) (*gls.Program, error) {
 	// Get vertex shader source
 	vertexSource, ok := sm.shadersm[progInfo.Vertex]
 	if !ok {
-		return nil, fmt.Errorf("Vertex shader:%s not found", progInfo.Vertex)
+		return nil, fmt.Errorf("%w: vertex %s", ErrShaderNotFound, progInfo.Vertex)
 	}
 	// Pre-process vertex shader source
-	vertexSource, err := sm.preprocess(vertexSource, defines)
+	vertexSource, vertexMap, err := sm.preprocess(progInfo.Vertex, vertexSource, specs, defines)
 	if err != nil {
 		return nil, err
 	}
@@ -205,11 +425,11 @@ func (sm *Shaman) GenProgram(specs *ShaderSpecs) (*gls.Program, error) {
 
 	// Get fragment shader source
 	fragSource, ok := sm.shadersm[progInfo.Fragment]
-	if err != nil {
-		return nil, fmt.Errorf("Fragment shader:%s not found", progInfo.Fragment)
+	if !ok {
+		return nil, fmt.Errorf("%w: fragment %s", ErrShaderNotFound, progInfo.Fragment)
 	}
 	// Pre-process fragment shader source
-	fragSource, err = sm.preprocess(fragSource, defines)
+	fragSource, fragMap, err := sm.preprocess(progInfo.Fragment, fragSource, specs, defines)
 	if err != nil {
 		return nil, err
 	}
@@ -217,14 +437,15 @@ func (sm *Shaman) GenProgram(specs *ShaderSpecs) (*gls.Program, error) {
 
 	// Checks for optional geometry shader compiled template
 	var geomSource = ""
+	var geomMap gls.SourceMap
 	if progInfo.Geometry != "" {
 		// Get geometry shader source
 		geomSource, ok = sm.shadersm[progInfo.Geometry]
 		if !ok {
-			return nil, fmt.Errorf("Geometry shader:%s not found", progInfo.Geometry)
+			return nil, fmt.Errorf("%w: geometry %s", ErrShaderNotFound, progInfo.Geometry)
 		}
 		// Pre-process geometry shader source
-		geomSource, err = sm.preprocess(geomSource, defines)
+		geomSource, geomMap, err = sm.preprocess(progInfo.Geometry, geomSource, specs, defines)
 		if err != nil {
 			return nil, err
 		}
@@ -232,10 +453,10 @@ func (sm *Shaman) GenProgram(specs *ShaderSpecs) (*gls.Program, error) {
 
 	// Creates shader program
 	prog := sm.gs.NewProgram()
-	prog.AddShader(gls.VERTEX_SHADER, vertexSource)
-	prog.AddShader(gls.FRAGMENT_SHADER, fragSource)
+	prog.AddShader(gls.VERTEX_SHADER, vertexSource, vertexMap)
+	prog.AddShader(gls.FRAGMENT_SHADER, fragSource, fragMap)
 	if progInfo.Geometry != "" {
-		prog.AddShader(gls.GEOMETRY_SHADER, geomSource)
+		prog.AddShader(gls.GEOMETRY_SHADER, geomSource, geomMap)
 	}
 	err = prog.Build()
 	if err != nil {
@@ -245,87 +466,139 @@ func (sm *Shaman) GenProgram(specs *ShaderSpecs) (*gls.Program, error) {
 	return prog, nil
 }
 
-func (sm *Shaman) preprocess(source string, defines map[string]string) (string, error) {
-
-	// If defines map supplied, generate prefix with glsl version directive first,
-	// followed by "#define" directives
-	var prefix = ""
-	if defines != nil { // This is only true for the outer call
-		prefix = fmt.Sprintf("#version %s\n", GLSL_VERSION)
-		for name, value := range defines {
-			prefix = prefix + fmt.Sprintf("#define %s %s\n", name, value)
-		}
+// preprocess preprocesses the specified source prefixing it with the glsl version
+// and extension directives from the specs and the "#define" directives contained
+// in the "defines" parameter and expanding its include directives.
+// Returns the preprocessed source and the map of its lines to the original sources.
+func (sm *Shaman) preprocess(name, source string, specs *ShaderSpecs, defines map[string]string) (string, gls.SourceMap, error) {
+
+	// Generate prefix with glsl version directive first, followed by
+	// "#extension" and "#define" directives
+	version := specs.Version
+	if version == "" {
+		version = GLSL_VERSION
+	}
+	lines := []string{fmt.Sprintf("#version %s", version)}
+	for _, ext := range specs.Extensions {
+		lines = append(lines, fmt.Sprintf("#extension %s : enable", ext))
+	}
+	for name, value := range defines {
+		lines = append(lines, fmt.Sprintf("#define %s %s", name, value))
+	}
+	smap := make(gls.SourceMap, len(lines))
+	for i := range smap {
+		smap[i] = gls.SourceLocation{File: "<defines>", Line: i + 1}
 	}
 
-	return sm.processIncludes(prefix+source, defines)
-}
-
-// preprocess preprocesses the specified source prefixing it with optional defines directives
-// contained in "defines" parameter and replaces '#include <name>' directives
-// by the respective source code of include chunk of the specified name.
-// The included "files" are also processed recursively.
-func (sm *Shaman) processIncludes(source string, defines map[string]string) (string, error) {
-
-	// Find all string submatches for the "#include <name>" directive
-	matches := rexInclude.FindAllStringSubmatch(source, 100)
-	if len(matches) == 0 {
-		return source, nil
+	lines, smap, err := sm.processIncludes([]string{name}, source, defines, lines, smap)
+	if err != nil {
+		return "", nil, err
 	}
+	return strings.Join(lines, "\n"), smap, nil
+}
 
-	// For each directive found, replace the name by the respective include chunk source code
-	//var newSource = source
-	for _, m := range matches {
-		incFullMatch := m[0]
+// processIncludes appends the lines of the specified source to the lines slice
+// replacing '#include <name>' directives by the respective source code of the
+// include chunk of the specified name. The included "files" are also processed recursively.
+// The chain parameter contains the names of the shader and include chunks being
+// processed, the last one being the name of the specified source, and is used
+// to detect include cycles.
+// The original location of each appended line is appended to the source map.
+func (sm *Shaman) processIncludes(chain []string, source string, defines map[string]string, lines []string, smap gls.SourceMap) ([]string, gls.SourceMap, error) {
+
+	name := chain[len(chain)-1]
+	for i, line := range strings.Split(source, "\n") {
+		loc := gls.SourceLocation{File: name, Line: i + 1}
+
+		// Check for the "#include <name> [quantity]" directive
+		m := rexInclude.FindStringSubmatch(line)
+		if m == nil {
+			lines = append(lines, line)
+			smap = append(smap, loc)
+			continue
+		}
 		incName := m[1]
 		incQuantityVariable := m[2]
 
 		// Get the source of the include chunk with the match <name>
 		incSource := sm.includes[incName]
 		if len(incSource) == 0 {
-			return "", fmt.Errorf("Include:[%s] not found", incName)
+			return nil, nil, fmt.Errorf("%w: [%s] (%s:%d)", ErrIncludeNotFound, incName, strings.Join(chain, " -> "), loc.Line)
 		}
 
-		// Preprocess the include chunk source code
-		incSource, err := sm.processIncludes(incSource, defines)
-		if err != nil {
-			return "", err
+		// Check for include cycles
+		for _, n := range chain[1:] {
+			if n == incName {
+				return nil, nil, fmt.Errorf("%w: %s -> %s", ErrIncludeCycle, strings.Join(chain, " -> "), incName)
+			}
 		}
 
-		// Skip line
-		incSource = "\n" + incSource
-
-		// Process include quantity variable if provided
+		// Get include quantity if quantity variable is provided
+		incQuantity := 0
 		if incQuantityVariable != "" {
 			incQuantityString, defined := defines[incQuantityVariable]
-			if defined { // Only process #include if quantity variable is defined
-				incQuantity, err := strconv.Atoi(incQuantityString)
-				if err != nil {
-					return "", err
-				}
-				// Check for iterated includes and populate index parameter
-				if incQuantity > 0 {
-					repeatedIncludeSource := ""
-					for i := 0; i < incQuantity; i++ {
-						// Replace all occurrences of the index parameter with the current index i.
-						repeatedIncludeSource += strings.Replace(incSource, indexParameter, strconv.Itoa(i), -1)
-					}
-					incSource = repeatedIncludeSource
-				}
-			} else {
-				incSource = ""
+			if !defined { // Only process #include if quantity variable is defined
+				continue
+			}
+			var err error
+			incQuantity, err = strconv.Atoi(incQuantityString)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: [%s] %s=%q (%s:%d)", ErrIncludeQuantity, incName, incQuantityVariable, incQuantityString, strings.Join(chain, " -> "), loc.Line)
+			}
+		}
+
+		// Preprocess the include chunk source code
+		incLines, incMap, err := sm.processIncludes(append(chain[:len(chain):len(chain)], incName), incSource, defines, nil, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Replace the include directive line by an empty line followed by the include chunk
+		lines = append(lines, "")
+		smap = append(smap, loc)
+		if incQuantity <= 0 {
+			lines = append(lines, incLines...)
+			smap = append(smap, incMap...)
+			continue
+		}
+		// Repeat iterated includes populating the index parameter
+		for idx := 0; idx < incQuantity; idx++ {
+			for _, incLine := range incLines {
+				// Replace all occurrences of the index parameter with the current index.
+				lines = append(lines, strings.Replace(incLine, indexParameter, strconv.Itoa(idx), -1))
 			}
+			smap = append(smap, incMap...)
 		}
+	}
+	return lines, smap, nil
+}
+
+// prepare copies other spec into this clearing the number
+// of lights of the types not used according to the UseLights flags.
+func (ss *ShaderSpecs) prepare(other *ShaderSpecs) {
 
-		// Replace all occurrences of the include directive with its processed source code
-		source = strings.Replace(source, incFullMatch, incSource, -1)
+	ss.copy(other)
+	if (ss.UseLights & material.UseLightAmbient) == 0 {
+		ss.AmbientLightsMax = 0
+	}
+	if (ss.UseLights & material.UseLightDirectional) == 0 {
+		ss.DirLightsMax = 0
+	}
+	if (ss.UseLights & material.UseLightPoint) == 0 {
+		ss.PointLightsMax = 0
+	}
+	if (ss.UseLights & material.UseLightSpot) == 0 {
+		ss.SpotLightsMax = 0
 	}
-	return source, nil
 }
 
 // copy copies other spec into this
 func (ss *ShaderSpecs) copy(other *ShaderSpecs) {
 
 	*ss = *other
+	if other.Extensions != nil {
+		ss.Extensions = append([]string(nil), other.Extensions...)
+	}
 	if other.Defines != nil {
 		ss.Defines = *gls.NewShaderDefines()
 		ss.Defines.Add(&other.Defines)
@@ -335,9 +608,17 @@ func (ss *ShaderSpecs) copy(other *ShaderSpecs) {
 // equals compares two ShaderSpecs and returns true if they are effectively equal.
 func (ss *ShaderSpecs) equals(other *ShaderSpecs) bool {
 
-	if ss.Name != other.Name {
+	if ss.Name != other.Name || ss.Version != other.Version {
 		return false
 	}
+	if len(ss.Extensions) != len(other.Extensions) {
+		return false
+	}
+	for i := range ss.Extensions {
+		if ss.Extensions[i] != other.Extensions[i] {
+			return false
+		}
+	}
 	if other.ShaderUnique {
 		return true
 	}
@@ -0,0 +1,111 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package renderer
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test expansion of include directives and mapping of the expanded lines
+func TestShamanIncludes(t *testing.T) {
+
+	includes := map[string]string{
+		"a":     "A1\n#include <b>\nA3",
+		"b":     "B1",
+		"item":  "x{i}",
+		"loop1": "#include <loop2>",
+		"loop2": "#include <loop1>",
+		"self":  "#include <self>",
+	}
+	tests := []struct {
+		name    string
+		source  string
+		defines map[string]string
+		lines   []string
+		smap    []string
+		err     error
+	}{
+		{
+			name:   "nested",
+			source: "S1\n#include <a>\nS3",
+			lines:  []string{"#version 330 core", "S1", "", "A1", "", "B1", "A3", "S3"},
+			smap:   []string{"<defines>:1", "main:1", "main:2", "a:1", "a:2", "b:1", "a:3", "main:3"},
+		},
+		{
+			name:    "quantity",
+			source:  "#include <item> [N]",
+			defines: map[string]string{"N": "2"},
+			lines:   []string{"#version 330 core", "#define N 2", "", "x0", "x1"},
+			smap:    []string{"<defines>:1", "<defines>:2", "main:1", "item:1", "item:1"},
+		},
+		{
+			name:   "undefined quantity",
+			source: "S1\n#include <item> [N]\nS3",
+			lines:  []string{"#version 330 core", "S1", "S3"},
+			smap:   []string{"<defines>:1", "main:1", "main:3"},
+		},
+		{
+			name:    "invalid quantity",
+			source:  "#include <item> [N]",
+			defines: map[string]string{"N": "two"},
+			err:     ErrIncludeQuantity,
+		},
+		{name: "cycle", source: "#include <loop1>", err: ErrIncludeCycle},
+		{name: "self include", source: "#include <self>", err: ErrIncludeCycle},
+		{name: "not found", source: "#include <none>", err: ErrIncludeNotFound},
+	}
+	for _, test := range tests {
+		sm := Shaman{includes: includes}
+		source, smap, err := sm.preprocess("main", test.source, &ShaderSpecs{Version: "330 core"}, test.defines)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if got := strings.Split(source, "\n"); strings.Join(got, "|") != strings.Join(test.lines, "|") {
+			t.Errorf("%s: got lines %q, want %q", test.name, got, test.lines)
+		}
+		got := make([]string, len(smap))
+		for i, loc := range smap {
+			got[i] = loc.File + ":" + strconv.Itoa(loc.Line)
+		}
+		if strings.Join(got, "|") != strings.Join(test.smap, "|") {
+			t.Errorf("%s: got source map %q, want %q", test.name, got, test.smap)
+		}
+	}
+}
+
+// Test mapping of shader info log line references to the original sources
+func TestShamanMapLog(t *testing.T) {
+
+	sm := Shaman{includes: map[string]string{"a": "A1\n#include <b>\nA3", "b": "B1"}}
+	source, smap, err := sm.preprocess("main", "S1\n#include <a>\nS3", &ShaderSpecs{Version: "330 core"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		log  string
+		want string
+	}{
+		{"0:6: error: B", "b:1: error: B\n    B1"},
+		{"ERROR: 0:4: 'A1' : syntax error", "ERROR: a:1: 'A1' : syntax error\n    A1"},
+		{"0(8) : error C0000: S", "main:3 : error C0000: S\n    S3"},
+		{"0:99: error: out of range", "0:99: error: out of range"},
+		{"no line reference", "no line reference"},
+	}
+	for _, test := range tests {
+		if got := smap.MapLog(test.log, source); got != test.want {
+			t.Errorf("MapLog(%q): got %q, want %q", test.log, got, test.want)
+		}
+	}
+}
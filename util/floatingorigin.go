@@ -0,0 +1,194 @@
+// Copyright 2016 The G3N Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"github.com/g3n/engine/camera"
+	"github.com/g3n/engine/core"
+	"github.com/g3n/engine/light"
+	"github.com/g3n/engine/math32"
+)
+
+// OnOriginShift is the event dispatched by FloatingOrigin after the world was re-centered.
+// The event parameter is a pointer to an OriginShiftEvent.
+const OnOriginShift = "util.OnOriginShift"
+
+// OriginShiftEvent describes a re-centering of the world performed by FloatingOrigin.
+type OriginShiftEvent struct {
+	Offset math32.Vector3 // Offset subtracted from the position of all top level nodes
+}
+
+// FloatingOrigin implements a floating origin manager.
+// It keeps the reference node (usually the camera) close to the world origin by
+// periodically translating all the top level nodes of the scene, so that float32
+// world coordinates keep their precision in very large worlds.
+// The absolute position of the current origin is accumulated in double precision.
+// Only the top level nodes are translated: deeper nodes move with their top level
+// ancestor, as their positions are relative to it.
+// Directional lights are never translated, as their position only defines the
+// light direction. The same applies to any directional light placed under a
+// translated node, so directional lights should be top level nodes of the scene.
+// Other top level nodes can be excluded from translation with Exclude.
+// Other subsystems holding world positions (e.g. physics simulations) should
+// subscribe to OnOriginShift and translate their state by -Offset.
+// This includes the world space target of a camera.OrbitControl, which is
+// translated automatically if the control is set with SetOrbitControl.
+type FloatingOrigin struct {
+	core.Dispatcher                      // Embedded event dispatcher
+	scene           core.INode           // Scene whose children are translated
+	ref             core.INode           // Reference node kept close to the origin
+	threshold       float32              // Maximum distance of the reference node from the origin
+	origin          [3]float64           // Absolute position of the current origin
+	excluded        map[core.INode]bool  // Top level nodes which are not translated
+	orbit           *camera.OrbitControl // Orbit control whose target is translated
+	ev              OriginShiftEvent     // Preallocated event
+}
+
+// NewFloatingOrigin creates and returns a pointer to a new floating origin manager
+// for the specified scene and reference node. The world is re-centered whenever the
+// reference node is farther than threshold units from the origin.
+func NewFloatingOrigin(scene, ref core.INode, threshold float32) *FloatingOrigin {
+
+	fo := new(FloatingOrigin)
+	fo.Dispatcher.Initialize()
+	fo.scene = scene
+	fo.ref = ref
+	fo.threshold = threshold
+	fo.excluded = make(map[core.INode]bool)
+	return fo
+}
+
+// SetThreshold sets the distance from the origin which triggers a re-centering.
+func (fo *FloatingOrigin) SetThreshold(threshold float32) {
+
+	fo.threshold = threshold
+}
+
+// Threshold returns the distance from the origin which triggers a re-centering.
+func (fo *FloatingOrigin) Threshold() float32 {
+
+	return fo.threshold
+}
+
+// SetReference sets the node which is kept close to the origin.
+func (fo *FloatingOrigin) SetReference(ref core.INode) {
+
+	fo.ref = ref
+}
+
+// Exclude sets whether the specified top level node is excluded from the
+// translation applied when the world is re-centered. Nodes which are not
+// positioned in world space, such as skyboxes following the camera, should be excluded.
+func (fo *FloatingOrigin) Exclude(node core.INode, state bool) {
+
+	if state {
+		fo.excluded[node] = true
+	} else {
+		delete(fo.excluded, node)
+	}
+}
+
+// Excluded returns if the specified node is excluded from translation.
+func (fo *FloatingOrigin) Excluded(node core.INode) bool {
+
+	return fo.excluded[node]
+}
+
+// SetOrbitControl sets the orbit control whose target is translated when the
+// world is re-centered, so the camera keeps orbiting the same point. It may be nil.
+func (fo *FloatingOrigin) SetOrbitControl(oc *camera.OrbitControl) {
+
+	fo.orbit = oc
+}
+
+// Origin returns the absolute (double precision) position of the current origin.
+func (fo *FloatingOrigin) Origin() (x, y, z float64) {
+
+	return fo.origin[0], fo.origin[1], fo.origin[2]
+}
+
+// SetOrigin sets the absolute position of the current origin without translating any node.
+func (fo *FloatingOrigin) SetOrigin(x, y, z float64) {
+
+	fo.origin = [3]float64{x, y, z}
+}
+
+// ToAbsolute converts the specified position relative to the current origin
+// to an absolute double precision position.
+func (fo *FloatingOrigin) ToAbsolute(pos *math32.Vector3) (x, y, z float64) {
+
+	return fo.origin[0] + float64(pos.X), fo.origin[1] + float64(pos.Y), fo.origin[2] + float64(pos.Z)
+}
+
+// FromAbsolute converts the specified absolute double precision position
+// to a position relative to the current origin.
+func (fo *FloatingOrigin) FromAbsolute(x, y, z float64) math32.Vector3 {
+
+	return math32.Vector3{
+		X: float32(x - fo.origin[0]),
+		Y: float32(y - fo.origin[1]),
+		Z: float32(z - fo.origin[2]),
+	}
+}
+
+// Update checks the distance of the reference node from the origin and
+// re-centers the world around it if it exceeds the threshold.
+// It should normally be called once per frame before rendering.
+// Returns true if the world was re-centered.
+func (fo *FloatingOrigin) Update() bool {
+
+	if fo.ref == nil || fo.threshold <= 0 {
+		return false
+	}
+	var pos math32.Vector3
+	fo.ref.GetNode().WorldPosition(&pos)
+	if pos.LengthSq() < fo.threshold*fo.threshold {
+		return false
+	}
+	fo.Shift(&pos)
+	return true
+}
+
+// Shift translates all the top level nodes of the scene by the negated offset,
+// moves the absolute origin by the offset and dispatches OnOriginShift.
+// Excluded nodes and directional lights are not translated.
+// The target of the orbit control set with SetOrbitControl is translated.
+// If the reference node is not part of the scene it is translated as well.
+func (fo *FloatingOrigin) Shift(offset *math32.Vector3) {
+
+	for _, ichild := range fo.scene.Children() {
+		if fo.excluded[ichild] {
+			continue
+		}
+		if _, ok := ichild.(*light.Directional); ok {
+			continue
+		}
+		translate(ichild.GetNode(), offset)
+	}
+	if fo.ref != nil && fo.ref.Parent() == nil && fo.ref != fo.scene {
+		translate(fo.ref.GetNode(), offset)
+	}
+	if fo.orbit != nil {
+		target := fo.orbit.Target()
+		target.Sub(offset)
+		fo.orbit.SetTarget(target)
+	}
+	fo.scene.UpdateMatrixWorld()
+
+	fo.origin[0] += float64(offset.X)
+	fo.origin[1] += float64(offset.Y)
+	fo.origin[2] += float64(offset.Z)
+
+	fo.ev.Offset = *offset
+	fo.Dispatch(OnOriginShift, &fo.ev)
+}
+
+// translate subtracts the specified offset from the position of the node.
+func translate(node *core.Node, offset *math32.Vector3) {
+
+	pos := node.Position()
+	pos.Sub(offset)
+	node.SetPositionVec(&pos)
+}
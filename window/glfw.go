@@ -232,6 +232,21 @@ func maxi(x, y int) int {
 // Init initializes the GlfwWindow singleton with the specified width, height, and title.
 func Init(width, height int, title string) error {
 
+	return initWindow(width, height, title, true)
+}
+
+// InitHidden initializes the GlfwWindow singleton as an invisible window with the specified
+// width and height in screen coordinates (the framebuffer size may differ on HiDPI screens).
+// It provides an OpenGL context for programs which only render offscreen or process
+// data on the GPU and should not show a window to the user.
+func InitHidden(width, height int) error {
+
+	return initWindow(width, height, "", false)
+}
+
+// initWindow initializes the GlfwWindow singleton with the specified width, height, title and visibility.
+func initWindow(width, height int, title string, visible bool) error {
+
 	// Panic if already created
 	if win != nil {
 		panic(fmt.Errorf("can only call window.Init() once"))
@@ -257,6 +272,9 @@ func Init(width, height int, title string) error {
 	glfw.WindowHint(glfw.ContextVersionMinor, 3)
 	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
 	glfw.WindowHint(glfw.Samples, 8)
+	if !visible {
+		glfw.WindowHint(glfw.Visible, glfw.False)
+	}
 	// Set OpenGL forward compatible context only for OSX because it is required for OSX.
 	// When this is set, glLineWidth(width) only accepts width=1.0 and generates an error
 	// for any other values although the spec says it should ignore unsupported widths